@@ -1,6 +1,10 @@
 package main
 
-import "carWash/internal/app"
+import (
+	"carWash/internal/app"
+	// buildings keep their timezone by name, the image may have no zoneinfo
+	_ "time/tzdata"
+)
 
 const (
 	configPath = "configs"
@@ -17,6 +21,10 @@ const (
 // @in header
 // @name Authorization
 
+// @securityDefinitions.apikey API_Key
+// @in header
+// @name X-API-Key
+
 func main() {
 	app.Run(configPath)
 }
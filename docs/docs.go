@@ -23,9 +23,14 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/manager/sign-up": {
-            "post": {
-                "description": "create member account",
+        "/admin/audit": {
+            "get": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "get the security audit log, user_id matches both the actor and the target user",
                 "consumes": [
                     "application/json"
                 ],
@@ -33,24 +38,45 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
                 "parameters": [
                     {
-                        "description": "manager sign-up",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.UserSignUpInput"
-                        }
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "user_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.codeResponse"
+                            "$ref": "#/definitions/domain.GetAllResponses"
                         }
                     },
                     "400": {
@@ -80,9 +106,14 @@ var doc = `{
                 }
             }
         },
-        "/auth/reset-password": {
-            "post": {
-                "description": "verify email for reset password",
+        "/admin/managers": {
+            "get": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "get manager applications, filter by status to get the review queue",
                 "consumes": [
                     "application/json"
                 ],
@@ -90,24 +121,36 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
+                "operationId": "get-all-managers",
                 "parameters": [
                     {
-                        "description": "enter email",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.PhoneNumberInput"
-                        }
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.codeResponse"
+                            "$ref": "#/definitions/domain.GetAllResponses"
                         }
                     },
                     "400": {
@@ -137,9 +180,14 @@ var doc = `{
                 }
             }
         },
-        "/auth/reset-password-confirm": {
+        "/admin/managers/{id}/approve": {
             "post": {
-                "description": "reset password",
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "approve manager application, the manager is notified by SMS",
                 "consumes": [
                     "application/json"
                 ],
@@ -147,17 +195,15 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
                 "parameters": [
                     {
-                        "description": "reset password",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.ResetPasswordInput"
-                        }
+                        "type": "string",
+                        "description": "manager user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -194,9 +240,14 @@ var doc = `{
                 }
             }
         },
-        "/auth/reset-password-verify-phone-number": {
+        "/admin/managers/{id}/reject": {
             "post": {
-                "description": "verify secret code for reset password",
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "reject manager application with a reason, the manager is notified by SMS",
                 "consumes": [
                     "application/json"
                 ],
@@ -204,16 +255,23 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
                 "parameters": [
                     {
-                        "description": "verify phone number",
+                        "type": "string",
+                        "description": "manager user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "reject input",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.VerifyPhoneNumberInput"
+                            "$ref": "#/definitions/domain.RejectManagerInput"
                         }
                     }
                 ],
@@ -251,14 +309,14 @@ var doc = `{
                 }
             }
         },
-        "/auth/set-password": {
-            "post": {
+        "/admin/users": {
+            "get": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "users change password",
+                "description": "get all users, search by phone number or name",
                 "consumes": [
                     "application/json"
                 ],
@@ -266,24 +324,46 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
+                "operationId": "get-all-users",
                 "parameters": [
                     {
-                        "description": "change password",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.SetPasswordInput"
-                        }
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "name": "is_banned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "user",
+                            "manager",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "name": "user_type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.okResponse"
+                            "$ref": "#/definitions/domain.GetAllResponses"
                         }
                     },
                     "400": {
@@ -313,14 +393,14 @@ var doc = `{
                 }
             }
         },
-        "/auth/set-phone-number-confirm": {
-            "post": {
+        "/admin/users/{id}": {
+            "get": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "update phone number execute",
+                "description": "get user by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -328,24 +408,23 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
+                "operationId": "get-user-by-id",
                 "parameters": [
                     {
-                        "description": "phone number confirm",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.ResetPhoneNumberInput"
-                        }
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.codeResponse"
+                            "$ref": "#/definitions/domain.AdminUser"
                         }
                     },
                     "400": {
@@ -375,14 +454,14 @@ var doc = `{
                 }
             }
         },
-        "/auth/set-phone-number-verify": {
+        "/admin/users/{id}/2fa/reset": {
             "post": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "update phone number verify",
+                "description": "reset user's 2FA when the app and the recovery codes are lost",
                 "consumes": [
                     "application/json"
                 ],
@@ -390,24 +469,22 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
                 "parameters": [
                     {
-                        "description": "phone number verify input",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.UpdateNumberInput"
-                        }
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.codeResponse"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -437,9 +514,14 @@ var doc = `{
                 }
             }
         },
-        "/auth/sign-in": {
+        "/admin/users/{id}/ban": {
             "post": {
-                "description": "user sign in",
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "ban user, the user's sessions are ended and sign-in is rejected",
                 "consumes": [
                     "application/json"
                 ],
@@ -447,16 +529,23 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
                 "parameters": [
                     {
-                        "description": "sign in info",
-                        "name": "input",
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ban input",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.signInInput"
+                            "$ref": "#/definitions/domain.BanUserInput"
                         }
                     }
                 ],
@@ -464,7 +553,7 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.tokenResponse"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -494,14 +583,14 @@ var doc = `{
                 }
             }
         },
-        "/auth/user": {
+        "/admin/users/{id}/comments": {
             "get": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "get user info",
+                "description": "get user comments",
                 "consumes": [
                     "application/json"
                 ],
@@ -509,13 +598,33 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
+                ],
+                "operationId": "get-user-comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.okResponse"
+                            "$ref": "#/definitions/domain.GetAllResponses"
                         }
                     },
                     "400": {
@@ -543,14 +652,16 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/users/{id}/feedbacks": {
+            "get": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "update user info",
+                "description": "get user feedbacks",
                 "consumes": [
                     "application/json"
                 ],
@@ -558,24 +669,33 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
+                "operationId": "get-user-feedbacks",
                 "parameters": [
                     {
-                        "description": "a user update info",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.UserUpdate"
-                        }
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.okResponse"
+                            "$ref": "#/definitions/domain.GetAllResponses"
                         }
                     },
                     "400": {
@@ -605,9 +725,14 @@ var doc = `{
                 }
             }
         },
-        "/auth/user/sign-up": {
-            "post": {
-                "description": "create member account",
+        "/admin/users/{id}/orders": {
+            "get": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "get user orders",
                 "consumes": [
                     "application/json"
                 ],
@@ -615,24 +740,33 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
+                "operationId": "get-user-orders",
                 "parameters": [
                     {
-                        "description": "user sign-up",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.UserSignUpInput"
-                        }
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.codeResponse"
+                            "$ref": "#/definitions/domain.GetAllResponses"
                         }
                     },
                     "400": {
@@ -662,9 +796,14 @@ var doc = `{
                 }
             }
         },
-        "/auth/verify": {
-            "post": {
-                "description": "verify user phone number",
+        "/admin/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "change user role, the user's sessions are ended",
                 "consumes": [
                     "application/json"
                 ],
@@ -672,22 +811,29 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
                 "parameters": [
                     {
-                        "description": "user verify",
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "role input",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.VerifyUserInput"
+                            "$ref": "#/definitions/domain.SetRoleInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/v1.okResponse"
                         }
@@ -719,9 +865,14 @@ var doc = `{
                 }
             }
         },
-        "/building": {
-            "get": {
-                "description": "get all buildings",
+        "/admin/users/{id}/unban": {
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "unban user",
                 "consumes": [
                     "application/json"
                 ],
@@ -729,55 +880,22 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "building"
+                    "admin"
                 ],
-                "operationId": "get-all-buildings",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "end_cost",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            1,
-                            2
-                        ],
-                        "type": "integer",
-                        "name": "pitch_extra",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            1,
-                            2,
-                            3
-                        ],
-                        "type": "integer",
-                        "name": "pitch_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "start_cost",
-                        "in": "query"
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.GetAllResponses"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -805,101 +923,30 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/auth/2fa": {
+            "get": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
+                "description": "get 2FA status of the account",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "building"
-                ],
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "building name",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "building address",
-                        "name": "address",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "building instagram",
-                        "name": "instagram",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "building description",
-                        "name": "description",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "building image",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            1,
-                            2
-                        ],
-                        "type": "integer",
-                        "description": "work time type(1 - always,2 -your own choice)",
-                        "name": "work_time",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "start of work time",
-                        "name": "start_time",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "end of work time",
-                        "name": "end_time",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "longtitude",
-                        "name": "longtitude",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "latitude",
-                        "name": "latitude",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "auth"
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.idResponse"
+                            "$ref": "#/definitions/domain.TwoFactorStatus"
                         }
                     },
                     "400": {
@@ -929,9 +976,14 @@ var doc = `{
                 }
             }
         },
-        "/building/{id}": {
-            "get": {
-                "description": "get building by id",
+        "/auth/2fa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "turn 2FA off, not allowed for admins",
                 "consumes": [
                     "application/json"
                 ],
@@ -939,23 +991,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "building"
+                    "auth"
                 ],
-                "operationId": "get-building-by-id",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "building id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "code from the app or a recovery code",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TwoFactorCodeInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Building"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -964,12 +1017,24 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -983,102 +1048,41 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/2fa/enable": {
+            "post": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "update  building",
+                "description": "finish 2FA enrollment with the first code from the app, recovery codes are shown only once",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "building"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "building id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "building name",
-                        "name": "name",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "building address",
-                        "name": "address",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "building instagram",
-                        "name": "instagram",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "building description",
-                        "name": "description",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "building image",
-                        "name": "image",
-                        "in": "formData"
-                    },
-                    {
-                        "enum": [
-                            1,
-                            2
-                        ],
-                        "type": "integer",
-                        "description": "work time type(1 - always,2 -your own choice)",
-                        "name": "work_time",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "start of work time",
-                        "name": "start_time",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "end of work time",
-                        "name": "end_time",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "longtitude",
-                        "name": "longtitude",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "latitude",
-                        "name": "latitude",
-                        "in": "formData"
+                        "description": "code from the app",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TwoFactorCodeInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.okResponse"
+                            "$ref": "#/definitions/domain.RecoveryCodes"
                         }
                     },
                     "400": {
@@ -1106,14 +1110,16 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/2fa/enroll": {
+            "post": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "delete building",
+                "description": "start 2FA enrollment, the uri is shown to the user as a QR code",
                 "consumes": [
                     "application/json"
                 ],
@@ -1121,22 +1127,13 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "building"
-                ],
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "building id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.okResponse"
+                            "$ref": "#/definitions/domain.TwoFactorEnrollment"
                         }
                     },
                     "400": {
@@ -1166,14 +1163,14 @@ var doc = `{
                 }
             }
         },
-        "/card": {
-            "get": {
+        "/auth/2fa/recovery-codes": {
+            "post": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "get all card",
+                "description": "replace all recovery codes with new ones",
                 "consumes": [
                     "application/json"
                 ],
@@ -1181,26 +1178,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "card"
+                    "auth"
                 ],
-                "operationId": "get-all-card",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query"
+                        "description": "code from the app or a recovery code",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TwoFactorCodeInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.GetAllResponses"
+                            "$ref": "#/definitions/domain.RecoveryCodes"
                         }
                     },
                     "400": {
@@ -1215,6 +1210,12 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1228,13 +1229,16 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/auth/account": {
+            "delete": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
+                "description": "delete the account, orders and reviews are kept without personal data",
                 "consumes": [
                     "application/json"
                 ],
@@ -1242,24 +1246,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "card"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "description": "card input",
-                        "name": "data",
+                        "description": "current password",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.Card"
+                            "$ref": "#/definitions/domain.DeleteAccountInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.idResponse"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -1268,12 +1272,24 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1289,38 +1305,37 @@ var doc = `{
                 }
             }
         },
-        "/card/{id}": {
+        "/auth/account/export": {
             "get": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "get card by id",
+                "description": "download all personal data, format=zip puts every section into its own file",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/zip"
                 ],
                 "tags": [
-                    "card"
+                    "auth"
                 ],
-                "operationId": "get-card-by-id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "card id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "json or zip",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Card"
+                            "$ref": "#/definitions/domain.AccountExport"
                         }
                     },
                     "400": {
@@ -1348,14 +1363,16 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/api-keys": {
+            "get": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "update  card",
+                "description": "get API keys of the manager, revoked keys included",
                 "consumes": [
                     "application/json"
                 ],
@@ -1363,31 +1380,16 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "card"
-                ],
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "card id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "foot card input",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.UpdateCard"
-                        }
-                    }
+                    "api-keys"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.okResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.APIKey"
+                            }
                         }
                     },
                     "400": {
@@ -1416,13 +1418,13 @@ var doc = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "delete card",
+                "description": "create API key for the given buildings and permissions, the key is shown only once",
                 "consumes": [
                     "application/json"
                 ],
@@ -1430,22 +1432,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "card"
+                    "api-keys"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "card id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "key scope",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIKeyInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/v1.okResponse"
+                            "$ref": "#/definitions/domain.CreatedAPIKey"
                         }
                     },
                     "400": {
@@ -1454,6 +1458,12 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1475,9 +1485,14 @@ var doc = `{
                 }
             }
         },
-        "/comment": {
-            "get": {
-                "description": "get all comments",
+        "/auth/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "revoke API key, requests with it are rejected right away",
                 "consumes": [
                     "application/json"
                 ],
@@ -1485,31 +1500,22 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "comment"
+                    "api-keys"
                 ],
-                "operationId": "get-all-comments",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "building_id",
-                        "in": "query"
+                        "type": "string",
+                        "description": "key id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.GetAllResponses"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -1537,13 +1543,16 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/email": {
             "post": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
+                "description": "attach an email to the account and send a verification token to it",
                 "consumes": [
                     "application/json"
                 ],
@@ -1551,24 +1560,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "comment"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "description": "comment info",
+                        "description": "email",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.Comment"
+                            "$ref": "#/definitions/domain.AttachEmailInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.idResponse"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -1598,14 +1607,9 @@ var doc = `{
                 }
             }
         },
-        "/favourite": {
-            "get": {
-                "security": [
-                    {
-                        "User_Auth": []
-                    }
-                ],
-                "description": "get all favourites",
+        "/auth/email/verify": {
+            "post": {
+                "description": "confirm the email with the token from the verification letter",
                 "consumes": [
                     "application/json"
                 ],
@@ -1613,26 +1617,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "favourite"
+                    "auth"
                 ],
-                "operationId": "get-all-favourites",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query"
+                        "description": "verification token",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.VerifyEmailInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.GetAllResponses"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -1660,14 +1662,16 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/guest/claim": {
             "post": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "create favourite",
+                "description": "turn the guest account into a full one by setting a password, orders are kept",
                 "consumes": [
                     "application/json"
                 ],
@@ -1675,24 +1679,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "favourite"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "description": "favourite create input",
+                        "description": "password for the account",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.Favourite"
+                            "$ref": "#/definitions/domain.ClaimGuestInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.idResponse"
+                            "$ref": "#/definitions/v1.tokenResponse"
                         }
                     },
                     "400": {
@@ -1722,14 +1726,9 @@ var doc = `{
                 }
             }
         },
-        "/favourite/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "User_Auth": []
-                    }
-                ],
-                "description": "get favourite by id",
+        "/auth/guest/code": {
+            "post": {
+                "description": "send a code for guest checkout, phones of registered accounts have to sign in",
                 "consumes": [
                     "application/json"
                 ],
@@ -1737,23 +1736,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "favourite"
+                    "auth"
                 ],
-                "operationId": "get-favourite-by-id",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "building id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "guest phone",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.GuestCodeInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Favourite"
+                            "$ref": "#/definitions/v1.codeResponse"
                         }
                     },
                     "400": {
@@ -1768,6 +1768,12 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1781,14 +1787,11 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "User_Auth": []
-                    }
-                ],
-                "description": "delete favourite",
+            }
+        },
+        "/auth/guest/verify": {
+            "post": {
+                "description": "verify the guest phone, the returned token can only book and list own orders",
                 "consumes": [
                     "application/json"
                 ],
@@ -1796,22 +1799,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "favourite"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "building id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "guest verify",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.GuestVerifyInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.okResponse"
+                            "$ref": "#/definitions/v1.guestTokenResponse"
                         }
                     },
                     "400": {
@@ -1826,6 +1831,12 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1841,9 +1852,14 @@ var doc = `{
                 }
             }
         },
-        "/feedback": {
-            "get": {
-                "description": "gets all feedbacks",
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "log out from the current device",
                 "consumes": [
                     "application/json"
                 ],
@@ -1851,26 +1867,13 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "feedback"
-                ],
-                "operationId": "get-all-feedback",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query"
-                    }
+                    "auth"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.GetAllResponses"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -1898,13 +1901,16 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/auth/manager/profile": {
+            "get": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
+                "description": "get manager business details and approval status",
                 "consumes": [
                     "application/json"
                 ],
@@ -1912,24 +1918,13 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "feedback"
-                ],
-                "parameters": [
-                    {
-                        "description": "feedback input",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.Feedback"
-                        }
-                    }
+                    "auth"
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.idResponse"
+                            "$ref": "#/definitions/domain.ManagerProfile"
                         }
                     },
                     "400": {
@@ -1959,9 +1954,9 @@ var doc = `{
                 }
             }
         },
-        "/notification": {
-            "get": {
-                "description": "gets all notifications",
+        "/auth/manager/sign-up": {
+            "post": {
+                "description": "create manager account, it stays pending until an admin approves it",
                 "consumes": [
                     "application/json"
                 ],
@@ -1969,26 +1964,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "auth"
                 ],
-                "operationId": "get-all-notification",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query"
+                        "description": "manager sign-up",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ManagerSignUpInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.GetAllResponses"
+                            "$ref": "#/definitions/v1.codeResponse"
                         }
                     },
                     "400": {
@@ -2003,6 +1996,12 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2016,13 +2015,11 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/refresh": {
             "post": {
-                "security": [
-                    {
-                        "User_Auth": []
-                    }
-                ],
+                "description": "refresh access token, the given refresh token is rotated and can not be used again",
                 "consumes": [
                     "application/json"
                 ],
@@ -2030,24 +2027,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "description": "notification input",
-                        "name": "data",
+                        "description": "refresh token",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.Notification"
+                            "$ref": "#/definitions/v1.refreshInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.idResponse"
+                            "$ref": "#/definitions/v1.tokenResponse"
                         }
                     },
                     "400": {
@@ -2056,8 +2053,8 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/v1.response"
                         }
@@ -2077,9 +2074,9 @@ var doc = `{
                 }
             }
         },
-        "/order": {
-            "get": {
-                "description": "gets all orders",
+        "/auth/reset-password": {
+            "post": {
+                "description": "verify email for reset password",
                 "consumes": [
                     "application/json"
                 ],
@@ -2087,40 +2084,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "auth"
                 ],
-                "operationId": "get-all-orders",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "name": "order_date",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            1,
-                            2
-                        ],
-                        "type": "integer",
-                        "name": "order_status",
-                        "in": "query"
+                        "description": "enter email",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PhoneNumberInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.GetAllResponses"
+                            "$ref": "#/definitions/v1.codeResponse"
                         }
                     },
                     "400": {
@@ -2135,6 +2116,12 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2148,13 +2135,11 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/reset-password-confirm": {
             "post": {
-                "security": [
-                    {
-                        "User_Auth": []
-                    }
-                ],
+                "description": "reset password",
                 "consumes": [
                     "application/json"
                 ],
@@ -2162,24 +2147,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "description": "order create input",
+                        "description": "reset password",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.order"
+                            "$ref": "#/definitions/domain.ResetPasswordInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.idResponse"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -2209,9 +2194,9 @@ var doc = `{
                 }
             }
         },
-        "/order/times": {
-            "get": {
-                "description": "gets all orders time",
+        "/auth/reset-password-verify-phone-number": {
+            "post": {
+                "description": "verify secret code for reset password",
                 "consumes": [
                     "application/json"
                 ],
@@ -2219,41 +2204,84 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "auth"
                 ],
-                "operationId": "get-all-order-times",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "verify phone number",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.VerifyPhoneNumberInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "name": "building_id",
-                        "in": "query"
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
                     },
-                    {
-                        "type": "number",
-                        "name": "order_date",
-                        "in": "query"
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
                     },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "security": [
                     {
-                        "type": "integer",
-                        "name": "pitch_id",
-                        "in": "query"
+                        "User_Auth": []
                     }
                 ],
+                "description": "get all active sessions of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.GetAllResponses"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Session"
+                            }
                         }
                     },
                     "400": {
@@ -2281,16 +2309,14 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/order/{id}": {
+            },
             "delete": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "delete order",
+                "description": "log out from all devices",
                 "consumes": [
                     "application/json"
                 ],
@@ -2298,16 +2324,7 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
-                ],
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "order id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
                 "responses": {
                     "200": {
@@ -2343,9 +2360,14 @@ var doc = `{
                 }
             }
         },
-        "/pitch": {
-            "get": {
-                "description": "get all pitch",
+        "/auth/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "revoke session of another device",
                 "consumes": [
                     "application/json"
                 ],
@@ -2353,31 +2375,22 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pitch"
+                    "auth"
                 ],
-                "operationId": "get-all-pitch",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "name": "building_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "session id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.GetAllResponses"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -2405,72 +2418,41 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/set-password": {
             "post": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
+                "description": "users change password",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pitch"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "building id",
-                        "name": "building_id",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "price for pitch",
-                        "name": "price",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "pitch image",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            1,
-                            2,
-                            3
-                        ],
-                        "type": "integer",
-                        "description": "pitch type",
-                        "name": "pitch_type",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            1,
-                            2
-                        ],
-                        "type": "integer",
-                        "description": "pitch extra",
-                        "name": "pitch_extra",
-                        "in": "formData"
+                        "description": "change password",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SetPasswordInput"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/v1.idResponse"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -2500,9 +2482,14 @@ var doc = `{
                 }
             }
         },
-        "/pitch/{id}": {
-            "get": {
-                "description": "get pitch by id",
+        "/auth/set-phone-number-confirm": {
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "update phone number execute",
                 "consumes": [
                     "application/json"
                 ],
@@ -2510,23 +2497,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pitch"
+                    "auth"
                 ],
-                "operationId": "get-pitch-by-id",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "pitch id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "phone number confirm",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ResetPhoneNumberInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Pitch"
+                            "$ref": "#/definitions/v1.codeResponse"
                         }
                     },
                     "400": {
@@ -2541,6 +2529,12 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2554,76 +2548,41 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/set-phone-number-verify": {
+            "post": {
                 "security": [
                     {
                         "User_Auth": []
                     }
                 ],
-                "description": "update  pitch",
+                "description": "update phone number verify",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pitch"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "pitch id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "building id",
-                        "name": "building_id",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "price for pitch",
-                        "name": "price",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "pitch image",
-                        "name": "image",
-                        "in": "formData"
-                    },
-                    {
-                        "enum": [
-                            1,
-                            2,
-                            3
-                        ],
-                        "type": "integer",
-                        "description": "pitch type",
-                        "name": "pitch_type",
-                        "in": "formData"
-                    },
-                    {
-                        "enum": [
-                            1,
-                            2
-                        ],
-                        "type": "integer",
-                        "description": "pitch extra",
-                        "name": "pitch_extra",
-                        "in": "formData"
+                        "description": "phone number verify input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateNumberInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/v1.okResponse"
+                            "$ref": "#/definitions/v1.codeResponse"
                         }
                     },
                     "400": {
@@ -2638,6 +2597,12 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2651,14 +2616,11 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "User_Auth": []
-                    }
-                ],
-                "description": "delete pitch",
+            }
+        },
+        "/auth/sign-in": {
+            "post": {
+                "description": "user sign in",
                 "consumes": [
                     "application/json"
                 ],
@@ -2666,22 +2628,30 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pitch"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "pitch id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "sign in info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.signInInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.okResponse"
+                            "$ref": "#/definitions/v1.tokenResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/v1.twoFactorChallengeResponse"
                         }
                     },
                     "400": {
@@ -2690,6 +2660,12 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2711,9 +2687,9 @@ var doc = `{
                 }
             }
         },
-        "/service": {
-            "get": {
-                "description": "get all service",
+        "/auth/sign-in/2fa": {
+            "post": {
+                "description": "second sign-in step for accounts with 2FA, takes a code from the app or a recovery code",
                 "consumes": [
                     "application/json"
                 ],
@@ -2721,26 +2697,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "service"
+                    "auth"
                 ],
-                "operationId": "get-all-service",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query"
+                        "description": "challenge from sign-in and the code",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TwoFactorSignInInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.GetAllResponses"
+                            "$ref": "#/definitions/v1.tokenResponse"
                         }
                     },
                     "400": {
@@ -2749,8 +2723,14 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/v1.response"
                         }
@@ -2768,8 +2748,16 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/auth/user": {
+            "get": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "get user info",
                 "consumes": [
                     "application/json"
                 ],
@@ -2777,24 +2765,13 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "service"
-                ],
-                "parameters": [
-                    {
-                        "description": "service input",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.FootService"
-                        }
-                    }
+                    "auth"
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/v1.idResponse"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -2822,11 +2799,14 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/service/{id}": {
-            "get": {
-                "description": "get service by id",
+            },
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "update user info",
                 "consumes": [
                     "application/json"
                 ],
@@ -2834,23 +2814,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "service"
+                    "auth"
                 ],
-                "operationId": "get-service-by-id",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "service id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "a user update info",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UserUpdate"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.FootService"
+                            "$ref": "#/definitions/v1.okResponse"
                         }
                     },
                     "400": {
@@ -2878,9 +2859,11 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "update  service",
+            }
+        },
+        "/auth/user/sign-up": {
+            "post": {
+                "description": "create member account",
                 "consumes": [
                     "application/json"
                 ],
@@ -2888,31 +2871,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "service"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "service id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "foot service input",
+                        "description": "user sign-up",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.UpdateFootService"
+                            "$ref": "#/definitions/v1.UserSignUpInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/v1.okResponse"
+                            "$ref": "#/definitions/v1.codeResponse"
                         }
                     },
                     "400": {
@@ -2927,6 +2903,12 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2940,9 +2922,11 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "delete service",
+            }
+        },
+        "/auth/verify": {
+            "post": {
+                "description": "verify user phone number",
                 "consumes": [
                     "application/json"
                 ],
@@ -2950,20 +2934,22 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "service"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "service id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "user verify",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.VerifyUserInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/v1.okResponse"
                         }
@@ -2980,6 +2966,12 @@ var doc = `{
                             "$ref": "#/definitions/v1.response"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2994,138 +2986,5059 @@ var doc = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Building": {
-            "type": "object",
-            "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "building_image": {
-                    "type": "string"
-                },
-                "count_graded_user": {
+        },
+        "/building": {
+            "get": {
+                "description": "get all buildings, q searches by name, address and description",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "operationId": "get-all-buildings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "end_cost",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 90,
+                        "minimum": -90,
+                        "type": "number",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 180,
+                        "minimum": -180,
+                        "type": "number",
+                        "name": "lng",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "name": "pitch_extra",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            1,
+                            2,
+                            3
+                        ],
+                        "type": "integer",
+                        "name": "pitch_type",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 200,
+                        "type": "string",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 20000000,
+                        "type": "number",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "distance"
+                        ],
+                        "type": "string",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "start_cost",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetAllResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "building name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "building address",
+                        "name": "address",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "building instagram",
+                        "name": "instagram",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "building description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "building image",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "description": "work time type(1 - always,2 -your own choice)",
+                        "name": "work_time",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "start of work time",
+                        "name": "start_time",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "end of work time",
+                        "name": "end_time",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "longtitude, -180 to 180",
+                        "name": "longtitude",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "latitude, -90 to 90",
+                        "name": "latitude",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the building, Asia/Almaty by default",
+                        "name": "timezone",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.idResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/map": {
+            "get": {
+                "description": "get buildings inside the map viewport, below zoom 14 they are grouped into clusters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "operationId": "get-buildings-map",
+                "parameters": [
+                    {
+                        "maximum": 180,
+                        "minimum": -180,
+                        "type": "number",
+                        "name": "east",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 90,
+                        "minimum": -90,
+                        "type": "number",
+                        "name": "north",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 90,
+                        "minimum": -90,
+                        "type": "number",
+                        "name": "south",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 180,
+                        "minimum": -180,
+                        "type": "number",
+                        "name": "west",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 22,
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "zoom",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MapResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/suggest": {
+            "get": {
+                "description": "autocomplete of building names and addresses by the typed text",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "operationId": "suggest-buildings",
+                "parameters": [
+                    {
+                        "maximum": 20,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 200,
+                        "type": "string",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.BuildingSuggestion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}": {
+            "get": {
+                "description": "get building by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "operationId": "get-building-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Building"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "update  building",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "building name",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "building address",
+                        "name": "address",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "building instagram",
+                        "name": "instagram",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "building description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "building image",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "description": "work time type(1 - always,2 -your own choice)",
+                        "name": "work_time",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "start of work time, sent together with end_time",
+                        "name": "start_time",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "end of work time, rebuilds the hours of every weekday",
+                        "name": "end_time",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "keep",
+                            "cancel"
+                        ],
+                        "type": "string",
+                        "description": "what to do with upcoming orders outside the new hours, without it the change is refused with 409",
+                        "name": "resolution",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "longtitude, -180 to 180, sent together with latitude",
+                        "name": "longtitude",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "latitude, -90 to 90, sent together with longtitude",
+                        "name": "latitude",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the building, the hours stay in local time so upcoming orders are checked as for new hours",
+                        "name": "timezone",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.scheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.scheduleResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "delete building",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}/images": {
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "add images to the building gallery, the first image of an empty gallery becomes the cover",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "images, the field may be repeated",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Image"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}/images/order": {
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "reorder the building gallery, ids of all its images in the new order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "image ids",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImageOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}/images/{imageId}": {
+            "delete": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "delete the image from the building gallery, the cover can not be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "image id",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}/images/{imageId}/cover": {
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "make the image the cover of the building",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "image id",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}/orders": {
+            "get": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "get building orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "access"
+                ],
+                "operationId": "get-building-orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "building_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "name": "order_date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "name": "order_status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetAllResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}/schedule": {
+            "get": {
+                "description": "get weekly opening hours and the upcoming exceptions of the building",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "operationId": "get-building-schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BuildingSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}/schedule/booking": {
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "set the slot length and the booking durations of the building in minutes, booked orders are not changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "durations are whole slots, max_duration may be null",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.BookingRules"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}/schedule/exceptions": {
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "close the building on a date or set shortened hours, an exception on the same date is replaced.\nOrders that do not fit are handled by the resolution as for the weekly hours",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "date as 2006-01-02, hours are required unless the building is closed",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduleExceptionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.scheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.scheduleResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}/schedule/exceptions/{exceptionId}": {
+            "delete": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "delete the schedule exception, the weekly hours apply again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "exception id",
+                        "name": "exceptionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}/schedule/week": {
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "set opening hours of weekdays, 1 is Monday and 7 is Sunday, days left out keep their hours.\nUpcoming orders outside the new hours are kept or cancelled with an SMS by the resolution, without it the change is refused with 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "building"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "hours as 15:04, close time may be 24:00, a close time before the open time is after midnight",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.WeekScheduleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.scheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.scheduleResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}/staff": {
+            "get": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "get building staff",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "access"
+                ],
+                "operationId": "get-staff",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Staff"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "add a registered user to the building staff or change their role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "access"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "staff input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.StaffInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.idResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/building/{id}/staff/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "remove user from the building staff",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "access"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/card": {
+            "get": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "get all card",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "card"
+                ],
+                "operationId": "get-all-card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetAllResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "card"
+                ],
+                "parameters": [
+                    {
+                        "description": "card input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.Card"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.idResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/card/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "get card by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "card"
+                ],
+                "operationId": "get-card-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Card"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "update  card",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "card"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "foot card input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateCard"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "delete card",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "card"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/comment": {
+            "get": {
+                "description": "get all comments",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "operationId": "get-all-comments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "building_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetAllResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "parameters": [
+                    {
+                        "description": "comment info",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.Comment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.idResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/favourite": {
+            "get": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "get all favourites",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favourite"
+                ],
+                "operationId": "get-all-favourites",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetAllResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "create favourite",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favourite"
+                ],
+                "parameters": [
+                    {
+                        "description": "favourite create input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.Favourite"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.idResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/favourite/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "get favourite by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favourite"
+                ],
+                "operationId": "get-favourite-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Favourite"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "delete favourite",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favourite"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "building id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/feedback": {
+            "get": {
+                "description": "gets all feedbacks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedback"
+                ],
+                "operationId": "get-all-feedback",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetAllResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedback"
+                ],
+                "parameters": [
+                    {
+                        "description": "feedback input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.Feedback"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.idResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notification": {
+            "get": {
+                "description": "gets all notifications",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "operationId": "get-all-notification",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetAllResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "parameters": [
+                    {
+                        "description": "notification input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.Notification"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.idResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/order": {
+            "get": {
+                "description": "gets all orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "operationId": "get-all-orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "building_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "name": "order_date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "name": "order_status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetAllResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "date is the business day, order_date in unix seconds is still accepted instead of it.\nstart_time and end_time are 15:04 in the local time of the building, the order takes whole slots of the pitch within the opening hours",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "parameters": [
+                    {
+                        "description": "order create input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.order"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.idResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/pitch/{id}/block": {
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "blocks times of the pitch for a booking taken by the venue or an integration, no confirmation is sent.\ndate is the business day, start_time and end_time are 15:04 in the local time of the building and take whole slots of the pitch within the opening hours",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pitch id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "order block input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.orderBlock"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.idResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/times": {
+            "get": {
+                "description": "gets the slots of the order date from the building schedule, booked ones are marked when pitch_id is given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "operationId": "get-all-order-times",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "building_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "name": "order_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "pitch_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetAllResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "delete order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "order id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pitch": {
+            "get": {
+                "description": "get all pitch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pitch"
+                ],
+                "operationId": "get-all-pitch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "building_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetAllResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pitch"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "building id",
+                        "name": "building_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "price for pitch",
+                        "name": "price",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "pitch image",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            1,
+                            2,
+                            3
+                        ],
+                        "type": "integer",
+                        "description": "pitch type",
+                        "name": "pitch_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "description": "pitch extra",
+                        "name": "pitch_extra",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.idResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pitch/{id}": {
+            "get": {
+                "description": "get pitch by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pitch"
+                ],
+                "operationId": "get-pitch-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pitch id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Pitch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "update  pitch",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pitch"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pitch id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "building id",
+                        "name": "building_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "price for pitch",
+                        "name": "price",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "pitch image",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            1,
+                            2,
+                            3
+                        ],
+                        "type": "integer",
+                        "description": "pitch type",
+                        "name": "pitch_type",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "description": "pitch extra",
+                        "name": "pitch_extra",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "delete pitch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pitch"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pitch id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pitch/{id}/booking": {
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "override the booking rules of the building for the pitch, null fields are taken from the building",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pitch"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "pitch id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "minutes, durations are whole slots",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PitchBookingRulesInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pitch/{id}/images": {
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "add images to the pitch gallery, the first image of an empty gallery becomes the cover",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pitch"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "pitch id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "images, the field may be repeated",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Image"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pitch/{id}/images/order": {
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "reorder the pitch gallery, ids of all its images in the new order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pitch"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "pitch id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "image ids",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImageOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pitch/{id}/images/{imageId}": {
+            "delete": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "delete the image from the pitch gallery, the cover can not be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pitch"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "pitch id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "image id",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pitch/{id}/images/{imageId}/cover": {
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    },
+                    {
+                        "API_Key": []
+                    }
+                ],
+                "description": "make the image the cover of the pitch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pitch"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "pitch id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "image id",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "get staff roles with their permissions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "access"
+                ],
+                "operationId": "get-roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Role"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/service": {
+            "get": {
+                "description": "get all service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "operationId": "get-all-service",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetAllResponses"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "parameters": [
+                    {
+                        "description": "service input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.FootService"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.idResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/{id}": {
+            "get": {
+                "description": "get service by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "operationId": "get-service-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "service id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.FootService"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "update  service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "service id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "foot service input",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateFootService"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "User_Auth": []
+                    }
+                ],
+                "description": "delete service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "service id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.okResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.APIKey": {
+            "type": "object",
+            "properties": {
+                "buildings": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "number"
+                },
+                "last_used_ip": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.APIKeyInput": {
+            "type": "object",
+            "required": [
+                "buildings",
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "buildings": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "permissions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.AccountExport": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MaskedCard"
+                    }
+                },
+                "comments": {},
+                "exported_at": {
+                    "type": "integer"
+                },
+                "favourites": {},
+                "feedbacks": {},
+                "orders": {},
+                "profile": {
+                    "$ref": "#/definitions/domain.User"
+                }
+            }
+        },
+        "domain.AdminUser": {
+            "type": "object",
+            "properties": {
+                "ban_reason": {
+                    "type": "string"
+                },
+                "banned_at": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_activated": {
+                    "type": "boolean"
+                },
+                "is_banned": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "registered_at": {
+                    "type": "number"
+                },
+                "user_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AttachEmailInput": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "domain.BanUserInput": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "domain.BookingRules": {
+            "type": "object",
+            "required": [
+                "min_duration",
+                "slot_length"
+            ],
+            "properties": {
+                "max_duration": {
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 30
+                },
+                "min_duration": {
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 30
+                },
+                "slot_length": {
+                    "type": "integer",
+                    "enum": [
+                        30,
+                        60,
+                        90
+                    ]
+                }
+            }
+        },
+        "domain.Building": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "building_image": {
+                    "type": "string"
+                },
+                "count_graded_user": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "end-time": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "grade": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Image"
+                    }
+                },
+                "instagram": {
+                    "type": "string"
+                },
+                "is_favourite": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "manager_id": {
+                    "type": "integer"
+                },
+                "max_duration": {
+                    "type": "integer"
+                },
+                "min_duration": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "slot_length": {
+                    "type": "integer"
+                },
+                "start-time": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "work_time": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.BuildingHours": {
+            "type": "object",
+            "properties": {
+                "close_time": {
+                    "type": "string"
+                },
+                "is_closed": {
+                    "type": "boolean"
+                },
+                "open_time": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "integer",
+                    "maximum": 7,
+                    "minimum": 1
+                }
+            }
+        },
+        "domain.BuildingSchedule": {
+            "type": "object",
+            "properties": {
+                "booking": {
+                    "$ref": "#/definitions/domain.BookingRules"
+                },
+                "exceptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ScheduleException"
+                    }
+                },
+                "week": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BuildingHours"
+                    }
+                }
+            }
+        },
+        "domain.BuildingSuggestion": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Card": {
+            "type": "object",
+            "properties": {
+                "cvv": {
+                    "type": "integer"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "full_number": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "description": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ClaimGuestInput": {
+            "type": "object",
+            "required": [
+                "confirm_password",
+                "password"
+            ],
+            "properties": {
+                "confirm_password": {
+                    "type": "string"
+                },
+                "device_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                }
+            }
+        },
+        "domain.CreatedAPIKey": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DeleteAccountInput": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Favourite": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.FootService": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "service_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GetAllResponses": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "extra_field": {},
+                "page_info": {
+                    "$ref": "#/definitions/domain.PaginationPage"
+                }
+            }
+        },
+        "domain.GuestCodeInput": {
+            "type": "object",
+            "required": [
+                "phone_number"
+            ],
+            "properties": {
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GuestVerifyInput": {
+            "type": "object",
+            "required": [
+                "phone_number",
+                "secret_code"
+            ],
+            "properties": {
+                "device_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "secret_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Image": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "is_cover": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ImageOrderInput": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "domain.ManagerProfile": {
+            "type": "object",
+            "required": [
+                "contact_name",
+                "contact_phone",
+                "legal_name",
+                "tax_id"
+            ],
+            "properties": {
+                "contact_email": {
+                    "type": "string"
+                },
+                "contact_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "contact_phone": {
                     "type": "string"
                 },
-                "end-time": {
-                    "type": "integer"
+                "created_at": {
+                    "type": "number"
                 },
-                "end_time": {
+                "legal_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
                     "type": "string"
                 },
-                "grade": {
+                "phone_number": {
+                    "type": "string"
+                },
+                "reject_reason": {
+                    "type": "string"
+                },
+                "reviewed_at": {
                     "type": "number"
                 },
-                "id": {
+                "reviewed_by": {
                     "type": "integer"
                 },
-                "instagram": {
+                "status": {
                     "type": "string"
                 },
-                "is_favourite": {
-                    "type": "boolean"
+                "tax_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.MapBuilding": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "building_image": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
                 },
                 "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string"
                 },
+                "price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.MapCluster": {
+            "type": "object",
+            "properties": {
+                "building_id": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
                 "longitude": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.MapResponse": {
+            "type": "object",
+            "properties": {
+                "buildings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MapBuilding"
+                    }
+                },
+                "clustered": {
+                    "type": "boolean"
+                },
+                "clusters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MapCluster"
+                    }
+                }
+            }
+        },
+        "domain.MaskedCard": {
+            "type": "object",
+            "properties": {
+                "full_name": {
                     "type": "string"
                 },
-                "manager_id": {
+                "id": {
                     "type": "integer"
                 },
-                "name": {
+                "number": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.PaginationPage": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "phone_number": {
+                "page": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Pitch": {
+            "type": "object",
+            "properties": {
+                "building_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image": {
                     "type": "string"
                 },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Image"
+                    }
+                },
+                "max_duration": {
+                    "type": "integer"
+                },
+                "min_duration": {
+                    "type": "integer"
+                },
+                "pitch_extra": {
+                    "type": "integer"
+                },
+                "pitch_type": {
+                    "type": "integer"
+                },
                 "price": {
                     "type": "integer"
                 },
-                "start-time": {
+                "slot_length": {
                     "type": "integer"
+                }
+            }
+        },
+        "domain.PitchBookingRulesInput": {
+            "type": "object",
+            "properties": {
+                "max_duration": {
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 30
                 },
-                "start_time": {
+                "min_duration": {
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 30
+                },
+                "slot_length": {
+                    "type": "integer",
+                    "enum": [
+                        30,
+                        60,
+                        90
+                    ]
+                }
+            }
+        },
+        "domain.RecoveryCodes": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.RejectManagerInput": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "domain.ResetPasswordInput": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "phone_number"
+            ],
+            "properties": {
+                "confirm_new_password": {
                     "type": "string"
                 },
-                "work_time": {
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ResetPhoneNumberInput": {
+            "type": "object",
+            "required": [
+                "phone_number",
+                "secret_code"
+            ],
+            "properties": {
+                "phone_number": {
+                    "type": "string"
+                },
+                "secret_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Role": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "domain.Card": {
+        "domain.ScheduleConflict": {
             "type": "object",
             "properties": {
-                "cvv": {
+                "end_at": {
+                    "type": "string"
+                },
+                "end_order_date": {
+                    "type": "number"
+                },
+                "order_date": {
+                    "type": "number"
+                },
+                "order_id": {
                     "type": "integer"
                 },
-                "full_name": {
+                "pitch_id": {
+                    "type": "integer"
+                },
+                "start_at": {
                     "type": "string"
                 },
-                "full_number": {
+                "start_order_date": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.ScheduleException": {
+            "type": "object",
+            "required": [
+                "date"
+            ],
+            "properties": {
+                "close_time": {
+                    "type": "string"
+                },
+                "date": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "user_id": {
-                    "type": "integer"
+                "is_closed": {
+                    "type": "boolean"
+                },
+                "open_time": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "domain.Favourite": {
+        "domain.ScheduleExceptionInput": {
             "type": "object",
+            "required": [
+                "date"
+            ],
             "properties": {
+                "close_time": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
+                },
+                "is_closed": {
+                    "type": "boolean"
+                },
+                "open_time": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "resolution": {
+                    "type": "string",
+                    "enum": [
+                        "keep",
+                        "cancel"
+                    ]
                 }
             }
         },
-        "domain.FootService": {
+        "domain.Session": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device_name": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "price": {
-                    "type": "integer"
+                "ip": {
+                    "type": "string"
                 },
-                "service_name": {
+                "is_current": {
+                    "type": "boolean"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "user_agent": {
                     "type": "string"
                 }
             }
         },
-        "domain.GetAllResponses": {
+        "domain.SetPasswordInput": {
             "type": "object",
+            "required": [
+                "confirm_new_password",
+                "current_password",
+                "new_password"
+            ],
             "properties": {
-                "data": {},
-                "extra_field": {},
-                "page_info": {
-                    "$ref": "#/definitions/domain.PaginationPage"
+                "confirm_new_password": {
+                    "type": "string"
+                },
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
                 }
             }
         },
-        "domain.PaginationPage": {
+        "domain.SetRoleInput": {
             "type": "object",
+            "required": [
+                "user_type"
+            ],
             "properties": {
-                "count": {
-                    "type": "integer"
-                },
-                "page": {
-                    "type": "integer"
-                },
-                "pages": {
-                    "type": "integer"
+                "user_type": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "manager",
+                        "admin"
+                    ]
                 }
             }
         },
-        "domain.Pitch": {
+        "domain.Staff": {
             "type": "object",
             "properties": {
                 "building_id": {
@@ -3134,76 +8047,112 @@ var doc = `{
                 "id": {
                     "type": "integer"
                 },
-                "image": {
+                "phone_number": {
                     "type": "string"
                 },
-                "manager_id": {
+                "role_id": {
                     "type": "integer"
                 },
-                "pitch_extra": {
-                    "type": "integer"
+                "role_name": {
+                    "type": "string"
                 },
-                "pitch_type": {
+                "user_id": {
                     "type": "integer"
                 },
-                "price": {
-                    "type": "integer"
+                "user_name": {
+                    "type": "string"
                 }
             }
         },
-        "domain.ResetPasswordInput": {
+        "domain.StaffInput": {
             "type": "object",
             "required": [
-                "new_password",
-                "phone_number"
+                "phone_number",
+                "role_id"
             ],
             "properties": {
-                "confirm_new_password": {
-                    "type": "string"
-                },
-                "new_password": {
-                    "type": "string",
-                    "maxLength": 64,
-                    "minLength": 8
-                },
                 "phone_number": {
                     "type": "string"
+                },
+                "role_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.ResetPhoneNumberInput": {
+        "domain.TwoFactorCodeInput": {
             "type": "object",
             "required": [
-                "phone_number",
-                "secret_code"
+                "code"
             ],
             "properties": {
-                "phone_number": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TwoFactorEnrollment": {
+            "type": "object",
+            "properties": {
+                "secret": {
                     "type": "string"
                 },
-                "secret_code": {
+                "uri": {
                     "type": "string"
                 }
             }
         },
-        "domain.SetPasswordInput": {
+        "domain.TwoFactorSignInInput": {
             "type": "object",
             "required": [
-                "confirm_new_password",
-                "current_password",
-                "new_password"
+                "challenge",
+                "code"
             ],
             "properties": {
-                "confirm_new_password": {
+                "challenge": {
                     "type": "string"
                 },
-                "current_password": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TwoFactorStatus": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.User": {
+            "type": "object",
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "new_password": {
-                    "type": "string",
-                    "maxLength": 64,
-                    "minLength": 8
+                "email_verified": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_activated": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "user_type": {
+                    "type": "string"
                 }
             }
         },
@@ -3218,6 +8167,17 @@ var doc = `{
                 }
             }
         },
+        "domain.VerifyEmailInput": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "domain.VerifyPhoneNumberInput": {
             "type": "object",
             "required": [
@@ -3248,6 +8208,29 @@ var doc = `{
                 }
             }
         },
+        "domain.WeekScheduleInput": {
+            "type": "object",
+            "required": [
+                "days"
+            ],
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.BuildingHours"
+                    }
+                },
+                "resolution": {
+                    "type": "string",
+                    "enum": [
+                        "keep",
+                        "cancel"
+                    ]
+                }
+            }
+        },
         "v1.Card": {
             "type": "object",
             "required": [
@@ -3320,6 +8303,51 @@ var doc = `{
                 }
             }
         },
+        "v1.ManagerSignUpInput": {
+            "type": "object",
+            "required": [
+                "confirm_password",
+                "contact_name",
+                "contact_phone",
+                "legal_name",
+                "password",
+                "phone_number",
+                "tax_id"
+            ],
+            "properties": {
+                "confirm_password": {
+                    "type": "string"
+                },
+                "contact_email": {
+                    "type": "string"
+                },
+                "contact_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "contact_phone": {
+                    "type": "string"
+                },
+                "legal_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "tax_id": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.Notification": {
             "type": "object",
             "properties": {
@@ -3415,6 +8443,14 @@ var doc = `{
                 }
             }
         },
+        "v1.guestTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.idResponse": {
             "type": "object",
             "properties": {
@@ -3432,15 +8468,23 @@ var doc = `{
         "v1.order": {
             "type": "object",
             "required": [
-                "order_date",
+                "end_time",
                 "phone_number",
                 "pitch_id",
-                "times"
+                "start_time"
             ],
             "properties": {
                 "card_id": {
                     "type": "integer"
                 },
+                "date": {
+                    "type": "string",
+                    "example": "2024-05-20"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "19:30"
+                },
                 "extra_info": {
                     "type": "string"
                 },
@@ -3462,11 +8506,51 @@ var doc = `{
                         "type": "integer"
                     }
                 },
-                "times": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "start_time": {
+                    "type": "string",
+                    "example": "18:00"
+                }
+            }
+        },
+        "v1.orderBlock": {
+            "type": "object",
+            "required": [
+                "date",
+                "end_time",
+                "start_time"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2024-05-20"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "19:30"
+                },
+                "extra_info": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "18:00"
+                }
+            }
+        },
+        "v1.refreshInput": {
+            "type": "object",
+            "required": [
+                "refresh"
+            ],
+            "properties": {
+                "refresh": {
+                    "type": "string"
                 }
             }
         },
@@ -3478,6 +8562,23 @@ var doc = `{
                 }
             }
         },
+        "v1.scheduleResponse": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ScheduleConflict"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.signInInput": {
             "type": "object",
             "required": [
@@ -3485,6 +8586,9 @@ var doc = `{
                 "phone_number"
             ],
             "properties": {
+                "device_name": {
+                    "type": "string"
+                },
                 "password": {
                     "type": "string"
                 },
@@ -3503,9 +8607,25 @@ var doc = `{
                     "type": "string"
                 }
             }
+        },
+        "v1.twoFactorChallengeResponse": {
+            "type": "object",
+            "properties": {
+                "challenge": {
+                    "type": "string"
+                },
+                "two_factor_required": {
+                    "type": "boolean"
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "API_Key": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "User_Auth": {
             "type": "apiKey",
             "name": "Authorization",
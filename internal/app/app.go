@@ -9,9 +9,13 @@ import (
 	"carWash/pkg/auth"
 	"carWash/pkg/database"
 	"carWash/pkg/database/redis"
+	"carWash/pkg/email"
 	"carWash/pkg/hash"
 	"carWash/pkg/logger"
 	"carWash/pkg/phone"
+	"carWash/pkg/ratelimit"
+	"carWash/pkg/sms"
+	"carWash/pkg/totp"
 	"context"
 	"errors"
 	"net/http"
@@ -36,13 +40,57 @@ func Run(configPath string) {
 		logger.Error(err)
 	}
 
-	hashes := hash.NewSHA1Hashes(cfg.Auth.PasswordSalt)
+	hashes := hash.NewArgon2Hashes(cfg.Auth.PasswordSalt)
 
 	otpNumberGenerator := phone.NewSecretGenerator()
 
-	tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+	var smsSender sms.Sender = sms.NewFileSender(cfg.SMS.FilePath)
+
+	if cfg.SMS.Provider == config.SMSProviderHTTP {
+		smsSender = sms.NewHTTPSender(cfg.SMS.HTTP.URL, cfg.SMS.HTTP.From, cfg.SMS.HTTP.APIKey, cfg.SMS.HTTP.Timeout)
+	}
+
+	smsTemplates, err := sms.NewTemplates(map[string]map[string]string{
+		sms.SecretCodeTemplate:      cfg.SMS.Templates.SecretCode,
+		sms.ManagerApprovedTemplate: cfg.SMS.Templates.ManagerApproved,
+		sms.ManagerRejectedTemplate: cfg.SMS.Templates.ManagerRejected,
+		sms.OrderCancelledTemplate:  cfg.SMS.Templates.OrderCancelled,
+	}, cfg.SMS.DefaultLanguage)
+	if err != nil {
+		// every OTP and cancellation message is rendered from them
+		logger.Error(err)
+		return
+	}
+
+	var emailSender email.Sender = email.NewFileSender(cfg.SMTP.From, cfg.Email.Dir)
+
+	if cfg.Email.Provider == config.EmailProviderSMTP {
+		emailSender, err = email.NewSMTPSender(cfg.SMTP.From, cfg.SMTP.Pass, cfg.SMTP.Host, cfg.SMTP.Port)
+		if err != nil {
+			// SMTP was asked for, a nil sender would panic on the first email instead
+			logger.Error(err)
+			return
+		}
+	}
+
+	// a new key is published for a cached key set plus a check interval, so every instance has loaded it before it signs
+	keyLead := auth.JWKSMaxAge + cfg.Auth.JWT.KeyCheckInterval
+
+	keyRing, err := auth.NewKeyRing(cfg.Auth.JWT.KeysDir, cfg.Auth.JWT.Algorithm, cfg.Auth.JWT.KeyRotation, keyLead, cfg.Auth.JWT.AccessTokenTTL)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go keyRing.Watch(ctx, cfg.Auth.JWT.KeyCheckInterval)
+
+	tokenManager, err := auth.NewManager(keyRing, cfg.Auth.JWT.SigningKey)
 	if err != nil {
 		logger.Error(err)
+		return
 	}
 
 	repository := repos.NewRepository(db)
@@ -55,9 +103,29 @@ func Run(configPath string) {
 		TokenManager:    tokenManager,
 		AccessTokenTTL:  cfg.Auth.JWT.AccessTokenTTL,
 		RefreshTokenTTL: cfg.Auth.JWT.RefreshTokenTTL,
+		GuestTokenTTL:   cfg.Auth.JWT.GuestTokenTTL,
+		SMSSender:       smsSender,
+		SMSTemplates:    smsTemplates,
+		OTP: service.OTPPolicy{
+			MaxAttempts:    cfg.OTP.MaxAttempts,
+			IPMaxAttempts:  cfg.OTP.IPMaxAttempts,
+			Lockout:        cfg.OTP.Lockout,
+			ResendCooldown: cfg.OTP.ResendCooldown,
+		},
+		EmailSender: emailSender,
+		Email:       cfg.Email,
+		TOTP:        totp.NewGenerator(cfg.TwoFactor.Issuer, cfg.TwoFactor.Skew),
+		TwoFactor: service.TwoFactorPolicy{
+			ChallengeTTL: cfg.TwoFactor.ChallengeTTL,
+		},
+		AuditRetention: cfg.Audit.Retention,
 	})
 
-	handlers := delivery.NewHandler(services, tokenManager, cfg.Auth.JWT.SigningKey)
+	go services.Audit.Watch(ctx, cfg.Audit.CleanupInterval)
+
+	rateLimiter := ratelimit.NewLimiter(red, "rate_limit:")
+
+	handlers := delivery.NewHandler(services, tokenManager, rateLimiter)
 
 	srv := server.NewServer(handlers.Init(cfg))
 
@@ -13,10 +13,38 @@ const (
 	defaultHTTPMaxHeaderMegabytes = 1
 	defaultAccessTokenTTL         = 15 * time.Minute
 	defaultRefreshTokenTTL        = 24 * time.Hour * 30
+	defaultGuestTokenTTL          = 24 * time.Hour
+	defaultJWTAlgorithm           = "RS256"
+	defaultJWTKeysDir             = "./keys"
+	defaultJWTKeyRotation         = 24 * time.Hour * 7
+	defaultJWTKeyCheckInterval    = time.Hour
 	defaultSecretCodeTTL          = 2 * time.Minute
+	defaultSMSProvider            = SMSProviderFile
+	defaultSMSLanguage            = "ru"
+	defaultSMSTimeout             = 5 * time.Second
+	defaultOTPMaxAttempts         = 5
+	defaultOTPIPMaxAttempts       = 20
+	defaultOTPLockout             = 15 * time.Minute
+	defaultOTPResendCooldown      = time.Minute
+	defaultRateLimit              = 120
+	defaultRateLimitWindow        = time.Minute
+	defaultEmailProvider          = EmailProviderFile
+	defaultEmailDir               = "./emails"
+	defaultEmailVerificationTTL   = 24 * time.Hour
+	defaultTwoFactorIssuer        = "Football"
+	defaultTwoFactorSkew          = 1
+	defaultTwoFactorChallengeTTL  = 5 * time.Minute
+	defaultAuditRetention         = 24 * time.Hour * 365
+	defaultAuditCleanupInterval   = 24 * time.Hour
 
 	EnvLocal = "local"
 	Prod     = "prod"
+
+	SMSProviderFile = "file"
+	SMSProviderHTTP = "http"
+
+	EmailProviderFile = "file"
+	EmailProviderSMTP = "smtp"
 )
 
 type (
@@ -28,6 +56,11 @@ type (
 		Redis       RedisConfig
 		Email       EmailConfig
 		SMTP        SMTPConfig
+		SMS         SMSConfig
+		OTP         OTPConfig
+		RateLimit   RateLimitConfig
+		TwoFactor   TwoFactorConfig
+		Audit       AuditConfig
 	}
 	PostgresConfig struct {
 		Host     string
@@ -54,13 +87,21 @@ type (
 		DB       int
 	}
 	JWTConfig struct {
-		AccessTokenTTL  time.Duration `mapstructure:"accessTokenTTL"`
-		RefreshTokenTTL time.Duration `mapstructure:"refreshTokenTTL"`
-		SigningKey      string
+		AccessTokenTTL   time.Duration `mapstructure:"accessTokenTTL"`
+		RefreshTokenTTL  time.Duration `mapstructure:"refreshTokenTTL"`
+		GuestTokenTTL    time.Duration `mapstructure:"guestTokenTTL"`
+		Algorithm        string        `mapstructure:"algorithm"`
+		KeysDir          string        `mapstructure:"keysDir"`
+		KeyRotation      time.Duration `mapstructure:"keyRotation"`
+		KeyCheckInterval time.Duration `mapstructure:"keyCheckInterval"`
+		SigningKey       string
 	}
 	EmailConfig struct {
-		Templates EmailTemplates
-		Subjects  EmailSubjects
+		Provider        string        `mapstructure:"provider"`
+		Dir             string        `mapstructure:"dir"`
+		VerificationTTL time.Duration `mapstructure:"verificationTTL"`
+		Templates       EmailTemplates
+		Subjects        EmailSubjects
 	}
 
 	EmailTemplates struct {
@@ -79,6 +120,60 @@ type (
 		From string `mapstructure:"from"`
 		Pass string
 	}
+
+	SMSConfig struct {
+		Provider        string        `mapstructure:"provider"`
+		FilePath        string        `mapstructure:"filePath"`
+		DefaultLanguage string        `mapstructure:"defaultLanguage"`
+		HTTP            SMSHTTPConfig `mapstructure:"http"`
+		Templates       SMSTemplates  `mapstructure:"templates"`
+	}
+
+	SMSHTTPConfig struct {
+		From    string        `mapstructure:"from"`
+		Timeout time.Duration `mapstructure:"timeout"`
+		URL     string
+		APIKey  string
+	}
+
+	SMSTemplates struct {
+		SecretCode      map[string]string `mapstructure:"secret_code"`
+		ManagerApproved map[string]string `mapstructure:"manager_approved"`
+		ManagerRejected map[string]string `mapstructure:"manager_rejected"`
+		OrderCancelled  map[string]string `mapstructure:"order_cancelled"`
+	}
+
+	OTPConfig struct {
+		MaxAttempts    int           `mapstructure:"maxAttempts"`
+		IPMaxAttempts  int           `mapstructure:"ipMaxAttempts"`
+		Lockout        time.Duration `mapstructure:"lockout"`
+		ResendCooldown time.Duration `mapstructure:"resendCooldown"`
+	}
+
+	RateLimitConfig struct {
+		Enabled bool              `mapstructure:"enabled"`
+		Default RateLimitPolicy   `mapstructure:"default"`
+		Routes  []RateLimitPolicy `mapstructure:"routes"`
+	}
+
+	TwoFactorConfig struct {
+		Issuer       string        `mapstructure:"issuer"`
+		Skew         int           `mapstructure:"skew"`
+		ChallengeTTL time.Duration `mapstructure:"challengeTTL"`
+	}
+
+	AuditConfig struct {
+		Retention       time.Duration `mapstructure:"retention"`
+		CleanupInterval time.Duration `mapstructure:"cleanupInterval"`
+	}
+
+	RateLimitPolicy struct {
+		Name   string        `mapstructure:"name"`
+		Method string        `mapstructure:"method"`
+		Prefix string        `mapstructure:"prefix"`
+		Limit  int           `mapstructure:"limit"`
+		Window time.Duration `mapstructure:"window"`
+	}
 )
 
 func Init(configPath string) (*Config, error) {
@@ -113,12 +208,30 @@ func unmarshal(cfg *Config) error {
 	if err := viper.UnmarshalKey("smtp", &cfg.SMTP); err != nil {
 		return err
 	}
+	if err := viper.UnmarshalKey("email", &cfg.Email); err != nil {
+		return err
+	}
 	if err := viper.UnmarshalKey("email.templates", &cfg.Email.Templates); err != nil {
 		return err
 	}
 	if err := viper.UnmarshalKey("email.subjects", &cfg.Email.Subjects); err != nil {
 		return err
 	}
+	if err := viper.UnmarshalKey("sms", &cfg.SMS); err != nil {
+		return err
+	}
+	if err := viper.UnmarshalKey("otp", &cfg.OTP); err != nil {
+		return err
+	}
+	if err := viper.UnmarshalKey("rateLimit", &cfg.RateLimit); err != nil {
+		return err
+	}
+	if err := viper.UnmarshalKey("twoFactor", &cfg.TwoFactor); err != nil {
+		return err
+	}
+	if err := viper.UnmarshalKey("audit", &cfg.Audit); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -140,6 +253,9 @@ func setFromEnv(cfg *Config) {
 
 	cfg.Auth.PasswordSalt = os.Getenv("PASSWORD_SALT")
 	cfg.Auth.JWT.SigningKey = os.Getenv("SIGNING_KEY")
+
+	cfg.SMS.HTTP.URL = os.Getenv("SMS_API_URL")
+	cfg.SMS.HTTP.APIKey = os.Getenv("SMS_API_KEY")
 }
 
 func parseConfigFile(folder, env string) error {
@@ -163,4 +279,27 @@ func populateDefaults() {
 	viper.SetDefault("http.timeouts.write", defaultHTTPRWTimeout)
 	viper.SetDefault("auth.accessTokenTTL", defaultAccessTokenTTL)
 	viper.SetDefault("auth.refreshTokenTTL", defaultRefreshTokenTTL)
+	viper.SetDefault("auth.guestTokenTTL", defaultGuestTokenTTL)
+	viper.SetDefault("auth.algorithm", defaultJWTAlgorithm)
+	viper.SetDefault("auth.keysDir", defaultJWTKeysDir)
+	viper.SetDefault("auth.keyRotation", defaultJWTKeyRotation)
+	viper.SetDefault("auth.keyCheckInterval", defaultJWTKeyCheckInterval)
+	viper.SetDefault("sms.provider", defaultSMSProvider)
+	viper.SetDefault("sms.defaultLanguage", defaultSMSLanguage)
+	viper.SetDefault("sms.http.timeout", defaultSMSTimeout)
+	viper.SetDefault("otp.maxAttempts", defaultOTPMaxAttempts)
+	viper.SetDefault("otp.ipMaxAttempts", defaultOTPIPMaxAttempts)
+	viper.SetDefault("otp.lockout", defaultOTPLockout)
+	viper.SetDefault("otp.resendCooldown", defaultOTPResendCooldown)
+	viper.SetDefault("rateLimit.default.name", "default")
+	viper.SetDefault("rateLimit.default.limit", defaultRateLimit)
+	viper.SetDefault("rateLimit.default.window", defaultRateLimitWindow)
+	viper.SetDefault("email.provider", defaultEmailProvider)
+	viper.SetDefault("email.dir", defaultEmailDir)
+	viper.SetDefault("email.verificationTTL", defaultEmailVerificationTTL)
+	viper.SetDefault("twoFactor.issuer", defaultTwoFactorIssuer)
+	viper.SetDefault("twoFactor.skew", defaultTwoFactorSkew)
+	viper.SetDefault("twoFactor.challengeTTL", defaultTwoFactorChallengeTTL)
+	viper.SetDefault("audit.retention", defaultAuditRetention)
+	viper.SetDefault("audit.cleanupInterval", defaultAuditCleanupInterval)
 }
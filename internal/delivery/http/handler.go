@@ -6,6 +6,7 @@ import (
 	v1 "carWash/internal/delivery/http/v1"
 	"carWash/internal/service"
 	"carWash/pkg/auth"
+	"carWash/pkg/ratelimit"
 	"fmt"
 	swagger "github.com/arsmn/fiber-swagger/v2"
 	"github.com/gofiber/fiber/v2"
@@ -15,11 +16,11 @@ import (
 type Handler struct {
 	services     *service.Service
 	tokenManager auth.TokenManager
-	signingKey   string
+	limiter      *ratelimit.Limiter
 }
 
-func NewHandler(services *service.Service, tokenManager auth.TokenManager, signingKey string) *Handler {
-	return &Handler{services: services, tokenManager: tokenManager, signingKey: signingKey}
+func NewHandler(services *service.Service, tokenManager auth.TokenManager, limiter *ratelimit.Limiter) *Handler {
+	return &Handler{services: services, tokenManager: tokenManager, limiter: limiter}
 }
 
 func (h *Handler) Init(cfg *config.Config) *fiber.App {
@@ -31,16 +32,23 @@ func (h *Handler) Init(cfg *config.Config) *fiber.App {
 	router := fiber.New()
 	router.Use(logger.New())
 	router.Get("/swagger/*", swagger.HandlerDefault)
+	router.Get("/.well-known/jwks.json", h.jwks)
 
-	h.initApi(router)
+	h.initApi(router, cfg)
 	router.Static("/media", "media")
 	return router
 }
 
-func (h *Handler) initApi(router *fiber.App) {
-	handler := v1.NewHandler(h.services, h.tokenManager, h.signingKey)
+func (h *Handler) initApi(router *fiber.App, cfg *config.Config) {
+	handler := v1.NewHandler(h.services, h.tokenManager, cfg.Environment, h.limiter, cfg.RateLimit)
 	api := router.Group("/api")
 	{
 		handler.Init(api)
 	}
 }
+
+// jwks publishes the public keys access tokens can be verified with.
+func (h *Handler) jwks(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(auth.JWKSMaxAge.Seconds())))
+	return c.JSON(h.tokenManager.JWKS())
+}
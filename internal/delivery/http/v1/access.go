@@ -0,0 +1,308 @@
+package v1
+
+import (
+	"carWash/internal/domain"
+	"carWash/pkg/validation/validationStructs"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+// buildingResolver finds the building a request targets, 0 means the request does not name one.
+type buildingResolver func(c *fiber.Ctx) (int, error)
+
+func buildingParam(name string) buildingResolver {
+	return func(c *fiber.Ctx) (int, error) {
+		return strconv.Atoi(c.Params(name))
+	}
+}
+
+func buildingForm(name string) buildingResolver {
+	return func(c *fiber.Ctx) (int, error) {
+		value := c.FormValue(name)
+		if value == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(value)
+	}
+}
+
+func (h *Handler) pitchBuilding(name string) buildingResolver {
+	return func(c *fiber.Ctx) (int, error) {
+		pitchId, err := strconv.Atoi(c.Params(name))
+		if err != nil {
+			return 0, err
+		}
+		return h.services.Access.GetPitchBuilding(pitchId)
+	}
+}
+
+// requirePermission lets the request through only if the user holds the permission on every
+// building the resolvers point to. Without resolvers the permission is checked globally.
+// Requests made with an API key are also limited to the key's buildings and permissions.
+func (h *Handler) requirePermission(permission string, resolvers ...buildingResolver) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userType, userId := getUser(c)
+
+		buildings := make([]int, 0, len(resolvers))
+
+		for _, resolve := range resolvers {
+			buildingId, err := resolve(c)
+
+			if err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+				}
+				return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+			}
+
+			if buildingId != 0 {
+				buildings = append(buildings, buildingId)
+			}
+		}
+
+		if len(buildings) == 0 {
+			buildings = append(buildings, 0)
+		}
+
+		for _, buildingId := range buildings {
+			ok, err := h.services.Access.HasPermission(userType, userId, buildingId, permission)
+
+			if err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+				}
+				if errors.Is(err, domain.ErrTwoFactorRequired) {
+					return c.Status(fiber.StatusForbidden).JSON(response{Message: err.Error()})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+			}
+
+			if apiKey, isKey := c.Locals(apiKeyCtx).(*domain.APIKey); isKey && !apiKey.Allows(buildingId, permission) {
+				ok = false
+			}
+
+			if !ok {
+				return c.Status(fiber.StatusForbidden).JSON(response{Message: domain.ErrPermissionDenied.Error()})
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+func (h *Handler) initAccessRoutes(api fiber.Router) {
+	api.Get("/roles", h.jwtMiddleware(), h.getRoles)
+
+	building := api.Group("/building")
+	{
+		staff := h.requirePermission(domain.PermStaffManage, buildingParam("id"))
+
+		building.Get("/:id/staff", h.apiKeyOrJWT(), staff, h.getStaff)
+		building.Post("/:id/staff", h.apiKeyOrJWT(), staff, h.addStaff)
+		building.Delete("/:id/staff/:userId", h.apiKeyOrJWT(), staff, h.removeStaff)
+
+		building.Get("/:id/orders", h.apiKeyOrJWT(), h.requirePermission(domain.PermOrderView, buildingParam("id")), h.getBuildingOrders)
+	}
+}
+
+// @Security User_Auth
+// @Tags access
+// @Description get staff roles with their permissions
+// @ID get-roles
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} domain.Role
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /roles [get]
+func (h *Handler) getRoles(c *fiber.Ctx) error {
+	roles, err := h.services.Access.GetRoles()
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(roles)
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags access
+// @Description get building staff
+// @ID get-staff
+// @Accept  json
+// @Produce  json
+// @Param id path string true "building id"
+// @Success 200 {array} domain.Staff
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/staff [get]
+func (h *Handler) getStaff(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	staff, err := h.services.Access.GetStaff(id)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(staff)
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags access
+// @Description add a registered user to the building staff or change their role
+// @ModuleID addStaff
+// @Accept  json
+// @Produce  json
+// @Param id path string true "building id"
+// @Param data body domain.StaffInput true "staff input"
+// @Success 201 {object} idResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/staff [post]
+func (h *Handler) addStaff(c *fiber.Ctx) error {
+	var input domain.StaffInput
+
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	staffId, err := h.services.Access.AddStaff(id, input)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditStaffAdd,
+		TargetType: domain.AuditTargetBuilding,
+		TargetId:   &id,
+		Details:    fmt.Sprintf("phone %s, role %d", input.PhoneNumber, input.RoleId),
+	}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotRegistered) || errors.Is(err, domain.ErrStaffIsOwner) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(idResponse{staffId})
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags access
+// @Description remove user from the building staff
+// @ModuleID removeStaff
+// @Accept  json
+// @Produce  json
+// @Param id path string true "building id"
+// @Param userId path string true "user id"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/staff/{userId} [delete]
+func (h *Handler) removeStaff(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	userId, err := strconv.Atoi(c.Params("userId"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	err = h.services.Access.RemoveStaff(id, userId)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditStaffRemove,
+		TargetType: domain.AuditTargetBuilding,
+		TargetId:   &id,
+		Details:    fmt.Sprintf("user %d", userId),
+	}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags access
+// @Description get building orders
+// @ID get-building-orders
+// @Accept  json
+// @Produce  json
+// @Param id path string true "building id"
+// @Param array query domain.Pagination  true "A page info"
+// @Param filter query domain.FilterForOrder true "filter for orders"
+// @Success 200 {object} domain.GetAllResponses
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/orders [get]
+func (h *Handler) getBuildingOrders(c *fiber.Ctx) error {
+	var (
+		page   domain.Pagination
+		filter domain.FilterForOrder
+	)
+
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err := c.QueryParser(&page); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	filter.BuildingId = id
+
+	list, err := h.services.Order.GetAll(c, page, domain.UserInfo{}, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
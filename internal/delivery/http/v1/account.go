@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"archive/zip"
+	"bytes"
+	"carWash/internal/domain"
+	"carWash/pkg/validation/validationStructs"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *Handler) initAccountRoutes(auth fiber.Router) {
+	auth.Get("/account/export", h.jwtMiddleware(), h.exportAccount)
+	auth.Delete("/account", h.jwtMiddleware(), h.deleteAccount)
+}
+
+// @Security User_Auth
+// @Tags auth
+// @Description download all personal data, format=zip puts every section into its own file
+// @ModuleID exportAccount
+// @Accept  json
+// @Produce  json
+// @Produce  application/zip
+// @Param format query string false "json or zip"
+// @Success 200 {object} domain.AccountExport
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/account/export [get]
+func (h *Handler) exportAccount(c *fiber.Ctx) error {
+	format := c.Query("format", "json")
+
+	if format != "json" && format != "zip" {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: "format должен быть json или zip"})
+	}
+
+	_, id := getUser(c)
+
+	export, err := h.services.Account.Export(c, id)
+
+	h.audit(c, domain.AuditEntry{Action: domain.AuditAccountExport, TargetType: domain.AuditTargetUser, TargetId: &id, Details: format}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	if format == "json" {
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="account-%d.json"`, id))
+		return c.Status(fiber.StatusOK).JSON(export)
+	}
+
+	archive, err := exportArchive(export)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="account-%d.zip"`, id))
+
+	return c.Status(fiber.StatusOK).Send(archive)
+}
+
+func exportArchive(export *domain.AccountExport) ([]byte, error) {
+	var buf bytes.Buffer
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"orders.json", export.Orders},
+		{"cards.json", export.Cards},
+		{"comments.json", export.Comments},
+		{"favourites.json", export.Favourites},
+		{"feedbacks.json", export.Feedbacks},
+	}
+
+	archive := zip.NewWriter(&buf)
+
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		if err = encoder.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// @Security User_Auth
+// @Tags auth
+// @Description delete the account, orders and reviews are kept without personal data
+// @ModuleID deleteAccount
+// @Accept  json
+// @Produce  json
+// @Param input body domain.DeleteAccountInput true "current password"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 401 {object} response
+// @Failure 409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/account [delete]
+func (h *Handler) deleteAccount(c *fiber.Ctx) error {
+	var input domain.DeleteAccountInput
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	userType, id := getUser(c)
+
+	// guest accounts have no password and are dropped with their token
+	if userType == guest {
+		return c.Status(fiber.StatusUnauthorized).JSON(response{Message: "нет доступа"})
+	}
+
+	err := h.services.UserAuth.DeleteAccount(id, input.Password)
+
+	h.audit(c, domain.AuditEntry{Action: domain.AuditAccountDelete, TargetType: domain.AuditTargetUser, TargetId: &id}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrWrongPassword) || errors.Is(err, domain.ErrInvalidPassword) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		if errors.Is(err, domain.ErrAccountHasBuildings) {
+			return c.Status(fiber.StatusConflict).JSON(response{Message: err.Error()})
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
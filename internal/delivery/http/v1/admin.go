@@ -0,0 +1,530 @@
+package v1
+
+import (
+	"carWash/internal/domain"
+	"carWash/pkg/validation/validationStructs"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+func (h *Handler) initAdminRoutes(api fiber.Router) {
+	admin := api.Group("/admin", h.jwtMiddleware(), h.isAdmin)
+	{
+		users := admin.Group("/users")
+		{
+			users.Get("", h.getAllUsers)
+			users.Get("/:id", h.getUserById)
+			users.Put("/:id/role", h.setUserRole)
+			users.Post("/:id/ban", h.banUser)
+			users.Post("/:id/unban", h.unbanUser)
+			users.Get("/:id/orders", h.getUserOrders)
+			users.Get("/:id/comments", h.getUserComments)
+			users.Get("/:id/feedbacks", h.getUserFeedbacks)
+			users.Post("/:id/2fa/reset", h.resetUserTwoFactor)
+		}
+
+		managers := admin.Group("/managers")
+		{
+			managers.Get("", h.getAllManagers)
+			managers.Post("/:id/approve", h.approveManager)
+			managers.Post("/:id/reject", h.rejectManager)
+		}
+
+		admin.Get("/audit", h.getAuditLog)
+	}
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description get all users, search by phone number or name
+// @ID get-all-users
+// @Accept  json
+// @Produce  json
+// @Param array query domain.Pagination  true "A page info"
+// @Param filter query domain.FilterForUser true "filter for users"
+// @Success 200 {object} domain.GetAllResponses
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/users [get]
+func (h *Handler) getAllUsers(c *fiber.Ctx) error {
+	var (
+		page   domain.Pagination
+		filter domain.FilterForUser
+	)
+
+	if err := c.QueryParser(&page); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(filter)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	list, err := h.services.Admin.GetAllUsers(c, page, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description get user by id
+// @ID get-user-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "user id"
+// @Success 200 {object} domain.AdminUser
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/users/{id} [get]
+func (h *Handler) getUserById(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	user, err := h.services.Admin.GetUserById(c, id)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description change user role, the user's sessions are ended
+// @ModuleID setUserRole
+// @Accept  json
+// @Produce  json
+// @Param id path string true "user id"
+// @Param data body domain.SetRoleInput true "role input"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/users/{id}/role [put]
+func (h *Handler) setUserRole(c *fiber.Ctx) error {
+	var input domain.SetRoleInput
+
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	_, adminId := getUser(c)
+
+	err = h.services.Admin.SetRole(c, adminId, id, input.UserType)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditUserRole,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   &id,
+		Details:    input.UserType,
+	}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrSelfModification) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description ban user, the user's sessions are ended and sign-in is rejected
+// @ModuleID banUser
+// @Accept  json
+// @Produce  json
+// @Param id path string true "user id"
+// @Param data body domain.BanUserInput true "ban input"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/users/{id}/ban [post]
+func (h *Handler) banUser(c *fiber.Ctx) error {
+	var input domain.BanUserInput
+
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	_, adminId := getUser(c)
+
+	err = h.services.Admin.Ban(c, adminId, id, input.Reason)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditUserBan,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   &id,
+		Details:    input.Reason,
+	}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrSelfModification) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description unban user
+// @ModuleID unbanUser
+// @Accept  json
+// @Produce  json
+// @Param id path string true "user id"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/users/{id}/unban [post]
+func (h *Handler) unbanUser(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	err = h.services.Admin.Unban(c, id)
+
+	h.audit(c, domain.AuditEntry{Action: domain.AuditUserUnban, TargetType: domain.AuditTargetUser, TargetId: &id}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description get user orders
+// @ID get-user-orders
+// @Accept  json
+// @Produce  json
+// @Param id path string true "user id"
+// @Param array query domain.Pagination  true "A page info"
+// @Success 200 {object} domain.GetAllResponses
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/users/{id}/orders [get]
+func (h *Handler) getUserOrders(c *fiber.Ctx) error {
+	var page domain.Pagination
+
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err := c.QueryParser(&page); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	list, err := h.services.Admin.GetUserOrders(c, page, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description get user comments
+// @ID get-user-comments
+// @Accept  json
+// @Produce  json
+// @Param id path string true "user id"
+// @Param array query domain.Pagination  true "A page info"
+// @Success 200 {object} domain.GetAllResponses
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/users/{id}/comments [get]
+func (h *Handler) getUserComments(c *fiber.Ctx) error {
+	var page domain.Pagination
+
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err := c.QueryParser(&page); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	list, err := h.services.Admin.GetUserComments(c, page, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description get user feedbacks
+// @ID get-user-feedbacks
+// @Accept  json
+// @Produce  json
+// @Param id path string true "user id"
+// @Param array query domain.Pagination  true "A page info"
+// @Success 200 {object} domain.GetAllResponses
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/users/{id}/feedbacks [get]
+func (h *Handler) getUserFeedbacks(c *fiber.Ctx) error {
+	var page domain.Pagination
+
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err := c.QueryParser(&page); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	list, err := h.services.Admin.GetUserFeedbacks(c, page, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description get manager applications, filter by status to get the review queue
+// @ID get-all-managers
+// @Accept  json
+// @Produce  json
+// @Param array query domain.Pagination  true "A page info"
+// @Param filter query domain.FilterForManager true "filter for managers"
+// @Success 200 {object} domain.GetAllResponses
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/managers [get]
+func (h *Handler) getAllManagers(c *fiber.Ctx) error {
+	var (
+		page   domain.Pagination
+		filter domain.FilterForManager
+	)
+
+	if err := c.QueryParser(&page); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	list, err := h.services.Admin.GetAllManagers(c, page, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description approve manager application, the manager is notified by SMS
+// @ModuleID approveManager
+// @Accept  json
+// @Produce  json
+// @Param id path string true "manager user id"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/managers/{id}/approve [post]
+func (h *Handler) approveManager(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	_, adminId := getUser(c)
+
+	err = h.services.Admin.ApproveManager(c, adminId, id)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditManagerApprove,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   &id,
+	}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrManagerAlreadyReviewed) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description reject manager application with a reason, the manager is notified by SMS
+// @ModuleID rejectManager
+// @Accept  json
+// @Produce  json
+// @Param id path string true "manager user id"
+// @Param data body domain.RejectManagerInput true "reject input"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/managers/{id}/reject [post]
+func (h *Handler) rejectManager(c *fiber.Ctx) error {
+	var input domain.RejectManagerInput
+
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	_, adminId := getUser(c)
+
+	err = h.services.Admin.RejectManager(c, adminId, id, input.Reason)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditManagerReject,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   &id,
+		Details:    input.Reason,
+	}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrManagerAlreadyReviewed) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description reset user's 2FA when the app and the recovery codes are lost
+// @ModuleID resetUserTwoFactor
+// @Accept  json
+// @Produce  json
+// @Param id path string true "user id"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/users/{id}/2fa/reset [post]
+func (h *Handler) resetUserTwoFactor(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	_, adminId := getUser(c)
+
+	err = h.services.Admin.ResetTwoFactor(c, adminId, id)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditTwoFactorReset,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   &id,
+	}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrSelfModification) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
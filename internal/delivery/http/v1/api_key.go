@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"carWash/internal/domain"
+	"carWash/pkg/validation/validationStructs"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+func (h *Handler) initAPIKeyRoutes(auth fiber.Router) {
+	auth.Get("/api-keys", h.jwtMiddleware(), h.isManager, h.getAPIKeys)
+	auth.Post("/api-keys", h.jwtMiddleware(), h.isManager, h.createAPIKey)
+	auth.Delete("/api-keys/:id", h.jwtMiddleware(), h.isManager, h.revokeAPIKey)
+}
+
+// @Security User_Auth
+// @Tags api-keys
+// @Description get API keys of the manager, revoked keys included
+// @ModuleID getAPIKeys
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} domain.APIKey
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/api-keys [get]
+func (h *Handler) getAPIKeys(c *fiber.Ctx) error {
+	_, id := getUser(c)
+
+	list, err := h.services.APIKey.GetAll(id)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// @Security User_Auth
+// @Tags api-keys
+// @Description create API key for the given buildings and permissions, the key is shown only once
+// @ModuleID createAPIKey
+// @Accept  json
+// @Produce  json
+// @Param input body domain.APIKeyInput true "key scope"
+// @Success 201 {object} domain.CreatedAPIKey
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/api-keys [post]
+func (h *Handler) createAPIKey(c *fiber.Ctx) error {
+	var input domain.APIKeyInput
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	userType, id := getUser(c)
+
+	key, err := h.services.APIKey.Create(id, userType, input)
+
+	entry := domain.AuditEntry{Action: domain.AuditAPIKeyCreate, TargetType: domain.AuditTargetAPIKey, Details: input.Name}
+
+	if key != nil {
+		entry.TargetId = &key.Id
+	}
+
+	h.audit(c, entry, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPermission) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			return c.Status(fiber.StatusForbidden).JSON(response{Message: err.Error()})
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(key)
+}
+
+// @Security User_Auth
+// @Tags api-keys
+// @Description revoke API key, requests with it are rejected right away
+// @ModuleID revokeAPIKey
+// @Accept  json
+// @Produce  json
+// @Param id path string true "key id"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/api-keys/{id} [delete]
+func (h *Handler) revokeAPIKey(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	_, userId := getUser(c)
+
+	err = h.services.APIKey.Revoke(id, userId)
+
+	h.audit(c, domain.AuditEntry{Action: domain.AuditAPIKeyRevoke, TargetType: domain.AuditTargetAPIKey, TargetId: &id}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
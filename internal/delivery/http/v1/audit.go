@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"carWash/internal/domain"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// audit records the outcome of a security relevant action, the actor is taken from
+// the access token unless the entry already names one.
+func (h *Handler) audit(c *fiber.Ctx, entry domain.AuditEntry, err error) {
+	if _, ok := c.Locals("user").(*jwt.Token); ok && entry.ActorId == nil {
+		userType, id := getUser(c)
+		entry.ActorId, entry.ActorType = &id, userType
+	}
+
+	if apiKey, ok := c.Locals(apiKeyCtx).(*domain.APIKey); ok && entry.ActorId == nil {
+		entry.ActorId, entry.ActorType = &apiKey.UserId, fmt.Sprintf("api_key:%d", apiKey.Id)
+	}
+
+	entry.Ip = c.IP()
+	entry.UserAgent = c.Get(fiber.HeaderUserAgent)
+	entry.Outcome = domain.AuditSuccess
+
+	if err != nil {
+		entry.Outcome = domain.AuditFailure
+
+		if entry.Details != "" {
+			entry.Details += ": "
+		}
+		entry.Details += err.Error()
+	}
+
+	h.services.Audit.Record(entry)
+}
+
+// @Security User_Auth
+// @Tags admin
+// @Description get the security audit log, user_id matches both the actor and the target user
+// @ModuleID getAuditLog
+// @Accept  json
+// @Produce  json
+// @Param array query domain.Pagination  true "A page info"
+// @Param filter query domain.FilterForAudit true "filter for audit log"
+// @Success 200 {object} domain.GetAllResponses
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /admin/audit [get]
+func (h *Handler) getAuditLog(c *fiber.Ctx) error {
+	var (
+		page   domain.Pagination
+		filter domain.FilterForAudit
+	)
+
+	if err := c.QueryParser(&page); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	list, err := h.services.Audit.GetAll(c, page, filter)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
@@ -1,18 +1,20 @@
 package v1
 
 import (
+	"carWash/internal/config"
 	"carWash/internal/domain"
 	"carWash/internal/service"
 	"carWash/pkg/validation/validationStructs"
 	"database/sql"
 	"errors"
 	"github.com/gofiber/fiber/v2"
-	jwtware "github.com/gofiber/jwt/v3"
+	"strconv"
 )
 
 type signInInput struct {
 	PhoneNumber string `json:"phone_number"         validate:"required"`
 	Password    string `json:"password"             validate:"required"`
+	DeviceName  string `json:"device_name"`
 }
 
 type tokenResponse struct {
@@ -20,10 +22,23 @@ type tokenResponse struct {
 	RefreshToken string `json:"refresh"`
 }
 
+type refreshInput struct {
+	RefreshToken string `json:"refresh" validate:"required"`
+}
+
 type codeResponse struct {
 	SecretCode string `json:"secret_code,omitempty"`
 }
 
+// newCodeResponse exposes the secret code only in the local environment,
+// everywhere else it is delivered by SMS.
+func (h *Handler) newCodeResponse(secret string) codeResponse {
+	if h.environment != config.EnvLocal {
+		return codeResponse{}
+	}
+	return codeResponse{SecretCode: secret}
+}
+
 type UpdateNumberInput struct {
 	NewPhoneNumber string `json:"new_phone_number" validate:"required"`
 }
@@ -39,14 +54,31 @@ func (h *Handler) initUserRoutes(api fiber.Router) {
 		auth.Post("/manager/sign-up", h.managerSignUp)
 		auth.Post("verify", h.verifyUser)
 		auth.Post("sign-in", h.userSignIn)
+		auth.Post("refresh", h.refreshTokens)
 		auth.Post("reset-password", h.resetPasswordVerify)
 		auth.Post("reset-password-verify-phone-number", h.verifyPhoneNumberForResetPassword)
 		auth.Post("reset-password-confirm", h.resetPasswordConfirm)
 
-		users := auth.Group("").Use(jwtware.New(
-			jwtware.Config{
-				SigningKey: []byte(h.signingKey),
-			}), isUser)
+		auth.Post("logout", h.jwtMiddleware(), h.logout)
+
+		sessions := auth.Group("/sessions", h.jwtMiddleware())
+		{
+			sessions.Get("", h.getSessions)
+			sessions.Delete("", h.revokeAllSessions)
+			sessions.Delete("/:id", h.revokeSession)
+		}
+
+		auth.Get("/manager/profile", h.jwtMiddleware(), isManagerAccount, h.getManagerProfile)
+
+		auth.Post("email", h.jwtMiddleware(), h.attachEmail)
+		auth.Post("email/verify", h.verifyEmail)
+
+		h.initTwoFactorRoutes(auth)
+		h.initGuestRoutes(auth)
+		h.initAPIKeyRoutes(auth)
+		h.initAccountRoutes(auth)
+
+		users := auth.Group("").Use(h.jwtMiddleware(), isUser)
 		{
 			users.Get("user", h.getUser)
 			users.Put("user", h.updateUser)
@@ -64,19 +96,25 @@ type UserSignUpInput struct {
 	ConfirmPassword string `json:"confirm_password"   validate:"required"`
 }
 
+type ManagerSignUpInput struct {
+	UserSignUpInput
+	domain.ManagerBusiness
+}
+
 // @Tags auth
-// @Description create member account
+// @Description create manager account, it stays pending until an admin approves it
 // @ModuleID managerSignUp
 // @Accept json
 // @Produce  json
-// @Param data body UserSignUpInput true "manager sign-up"
+// @Param data body ManagerSignUpInput true "manager sign-up"
 // @Success 201 {object} codeResponse
 // @Failure 400,404 {object} response
+// @Failure 429 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /auth/manager/sign-up [post]
 func (h *Handler) managerSignUp(c *fiber.Ctx) error {
-	var input UserSignUpInput
+	var input ManagerSignUpInput
 
 	if err := c.BodyParser(&input); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
@@ -99,16 +137,23 @@ func (h *Handler) managerSignUp(c *fiber.Ctx) error {
 		Password:        input.Password,
 		ConfirmPassword: input.ConfirmPassword,
 		UserType:        manager,
+		Language:        c.Get(fiber.HeaderAcceptLanguage),
+		Business:        &input.ManagerBusiness,
 	})
 
 	if err != nil {
+		var retryErr *domain.RetryAfterError
+		if errors.As(err, &retryErr) {
+			return retryAfterResponse(c, retryErr)
+		}
+
 		if errors.Is(err, domain.ErrPasswordNotMatch) {
 			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(codeResponse{SecretCode: secret})
+	return c.Status(fiber.StatusCreated).JSON(h.newCodeResponse(secret))
 }
 
 // @Tags auth
@@ -119,6 +164,7 @@ func (h *Handler) managerSignUp(c *fiber.Ctx) error {
 // @Param data body UserSignUpInput true "user sign-up"
 // @Success 201 {object} codeResponse
 // @Failure 400,404 {object} response
+// @Failure 429 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /auth/user/sign-up [post]
@@ -146,16 +192,22 @@ func (h *Handler) userSignUp(c *fiber.Ctx) error {
 		Password:        input.Password,
 		ConfirmPassword: input.ConfirmPassword,
 		UserType:        user,
+		Language:        c.Get(fiber.HeaderAcceptLanguage),
 	})
 
 	if err != nil {
+		var retryErr *domain.RetryAfterError
+		if errors.As(err, &retryErr) {
+			return retryAfterResponse(c, retryErr)
+		}
+
 		if errors.Is(err, domain.ErrPasswordNotMatch) {
 			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(codeResponse{SecretCode: secret})
+	return c.Status(fiber.StatusCreated).JSON(h.newCodeResponse(secret))
 }
 
 // @Tags auth
@@ -166,6 +218,7 @@ func (h *Handler) userSignUp(c *fiber.Ctx) error {
 // @Param data body domain.VerifyUserInput true "user verify"
 // @Success 201 {object} okResponse
 // @Failure 400,404 {object} response
+// @Failure 429 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /auth/verify [post]
@@ -176,9 +229,14 @@ func (h *Handler) verifyUser(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 	}
 
-	err := h.services.UserAuth.Verify(input)
+	err := h.services.UserAuth.Verify(input, c.IP())
 
 	if err != nil {
+		var retryErr *domain.RetryAfterError
+		if errors.As(err, &retryErr) {
+			return retryAfterResponse(c, retryErr)
+		}
+
 		if errors.Is(err, domain.ErrInvalidSecretCode) || errors.Is(err, domain.ErrUserAlreadyExist) {
 			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 		}
@@ -195,7 +253,9 @@ func (h *Handler) verifyUser(c *fiber.Ctx) error {
 // @Produce  json
 // @Param input body signInInput true "sign in info"
 // @Success 200 {object} tokenResponse
+// @Success 202 {object} twoFactorChallengeResponse
 // @Failure 400,404 {object} response
+// @Failure 403 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /auth/sign-in [post]
@@ -216,22 +276,218 @@ func (h *Handler) userSignIn(c *fiber.Ctx) error {
 		Password:    input.Password,
 	}
 
-	res, err := h.services.UserAuth.UserSignIn(user)
+	device := domain.Device{
+		Name:      input.DeviceName,
+		Ip:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}
+
+	res, err := h.services.UserAuth.UserSignIn(user, device)
+
+	entry := domain.AuditEntry{Action: domain.AuditSignIn, Details: input.PhoneNumber}
+
+	if res != nil {
+		entry.ActorId = &res.UserId
+
+		if res.TwoFactorChallenge != "" {
+			entry.Details += ", second factor required"
+		}
+	}
+
+	h.audit(c, entry, err)
 
 	if err != nil {
 
 		if errors.Is(err, domain.ErrUserDoesNotExist) {
 			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 		}
+		if errors.Is(err, domain.ErrUserBanned) {
+			return c.Status(fiber.StatusForbidden).JSON(response{Message: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
 	}
 
+	if res.TwoFactorChallenge != "" {
+		return c.Status(fiber.StatusAccepted).JSON(twoFactorChallengeResponse{
+			TwoFactorRequired: true,
+			Challenge:         res.TwoFactorChallenge,
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(tokenResponse{
 		AccessToken:  res.AccessToken,
 		RefreshToken: res.RefreshToken,
 	})
 }
 
+// @Tags auth
+// @Description refresh access token, the given refresh token is rotated and can not be used again
+// @ModuleID refreshTokens
+// @Accept  json
+// @Produce  json
+// @Param input body refreshInput true "refresh token"
+// @Success 200 {object} tokenResponse
+// @Failure 400,401 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/refresh [post]
+func (h *Handler) refreshTokens(c *fiber.Ctx) error {
+	var input refreshInput
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	device := domain.Device{
+		Ip:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}
+
+	res, err := h.services.UserAuth.RefreshTokens(input.RefreshToken, device)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRefreshToken) || errors.Is(err, domain.ErrRefreshTokenExpired) || errors.Is(err, domain.ErrRefreshTokenReused) {
+			return c.Status(fiber.StatusUnauthorized).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(tokenResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+	})
+}
+
+// @Tags auth
+// @Security User_Auth
+// @Description log out from the current device
+// @ModuleID logout
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/logout [post]
+func (h *Handler) logout(c *fiber.Ctx) error {
+	_, userId := getUser(c)
+
+	sessionId := getSessionId(c)
+
+	err := h.services.UserAuth.RevokeSession(sessionId, userId)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditSessionRevoke,
+		TargetType: domain.AuditTargetSession,
+		TargetId:   &sessionId,
+	}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Tags auth
+// @Security User_Auth
+// @Description get all active sessions of the user
+// @ModuleID getSessions
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} domain.Session
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/sessions [get]
+func (h *Handler) getSessions(c *fiber.Ctx) error {
+	_, userId := getUser(c)
+
+	list, err := h.services.UserAuth.GetSessions(userId, getSessionId(c))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// @Tags auth
+// @Security User_Auth
+// @Description revoke session of another device
+// @ModuleID revokeSession
+// @Accept  json
+// @Produce  json
+// @Param id path string true "session id"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/sessions/{id} [delete]
+func (h *Handler) revokeSession(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	_, userId := getUser(c)
+
+	err = h.services.UserAuth.RevokeSession(id, userId)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditSessionRevoke,
+		TargetType: domain.AuditTargetSession,
+		TargetId:   &id,
+	}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Tags auth
+// @Security User_Auth
+// @Description log out from all devices
+// @ModuleID revokeAllSessions
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/sessions [delete]
+func (h *Handler) revokeAllSessions(c *fiber.Ctx) error {
+	_, userId := getUser(c)
+
+	err := h.services.UserAuth.RevokeAllSessions(userId)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditSessionsRevoke,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   &userId,
+	}, err)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
 // @Tags auth
 // @Security User_Auth
 // @Description users change password
@@ -261,6 +517,13 @@ func (h *Handler) userSetPassword(c *fiber.Ctx) error {
 	_, id := getUser(c)
 
 	err := h.services.UserAuth.SetPassword(id, input)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditPasswordChange,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   &id,
+	}, err)
+
 	if err != nil {
 		if errors.Is(err, domain.ErrInvalidPassword) || errors.Is(err, domain.ErrPasswordNotMatch) {
 			return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
@@ -278,6 +541,7 @@ func (h *Handler) userSetPassword(c *fiber.Ctx) error {
 // @Param data body PhoneNumberInput true "enter email"
 // @Success 201 {object} codeResponse
 // @Failure 400,404 {object} response
+// @Failure 429 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /auth/reset-password [post]
@@ -294,9 +558,14 @@ func (h *Handler) resetPasswordVerify(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(errs)
 	}
 
-	secret, err := h.services.UserAuth.ResetPassword(input.PhoneNumber)
+	secret, err := h.services.UserAuth.ResetPassword(input.PhoneNumber, c.Get(fiber.HeaderAcceptLanguage))
 
 	if err != nil {
+		var retryErr *domain.RetryAfterError
+		if errors.As(err, &retryErr) {
+			return retryAfterResponse(c, retryErr)
+		}
+
 		if errors.Is(err, domain.ErrUserNotRegistered) {
 			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 		}
@@ -304,7 +573,7 @@ func (h *Handler) resetPasswordVerify(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(codeResponse{secret})
+	return c.Status(fiber.StatusCreated).JSON(h.newCodeResponse(secret))
 }
 
 // @Tags auth
@@ -315,6 +584,7 @@ func (h *Handler) resetPasswordVerify(c *fiber.Ctx) error {
 // @Param data body domain.VerifyPhoneNumberInput true "verify phone number"
 // @Success 200 {object} okResponse
 // @Failure 400,404 {object} response
+// @Failure 429 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /auth/reset-password-verify-phone-number [post]
@@ -331,9 +601,13 @@ func (h *Handler) verifyPhoneNumberForResetPassword(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(errs)
 	}
 
-	err := h.services.UserAuth.VerifyPhoneNumber(input)
+	err := h.services.UserAuth.VerifyPhoneNumber(input, c.IP())
 
 	if err != nil {
+		var retryErr *domain.RetryAfterError
+		if errors.As(err, &retryErr) {
+			return retryAfterResponse(c, retryErr)
+		}
 
 		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, domain.ErrInvalidSecretCode) {
 			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
@@ -371,6 +645,8 @@ func (h *Handler) resetPasswordConfirm(c *fiber.Ctx) error {
 
 	err := h.services.UserAuth.ResetPasswordConfirm(input)
 
+	h.audit(c, domain.AuditEntry{Action: domain.AuditPasswordReset, Details: input.PhoneNumber}, err)
+
 	if err != nil {
 
 		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, domain.ErrInvalidSecretCode) || errors.Is(err, domain.ErrPasswordNotMatch) {
@@ -392,6 +668,7 @@ func (h *Handler) resetPasswordConfirm(c *fiber.Ctx) error {
 // @Param data body UpdateNumberInput true "phone number verify input"
 // @Success 201 {object} codeResponse
 // @Failure 400,404 {object} response
+// @Failure 429 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /auth/set-phone-number-verify [post]
@@ -406,13 +683,18 @@ func (h *Handler) updateNumber(c *fiber.Ctx) error {
 		PhoneNumber: input.NewPhoneNumber,
 	}
 
-	secret, err := h.services.UserAuth.UpdatePhoneNumberVerify(inp)
+	secret, err := h.services.UserAuth.UpdatePhoneNumberVerify(inp, c.Get(fiber.HeaderAcceptLanguage))
 
 	if err != nil {
+		var retryErr *domain.RetryAfterError
+		if errors.As(err, &retryErr) {
+			return retryAfterResponse(c, retryErr)
+		}
+
 		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(codeResponse{SecretCode: secret})
+	return c.Status(fiber.StatusOK).JSON(h.newCodeResponse(secret))
 }
 
 // @Security User_Auth
@@ -424,6 +706,7 @@ func (h *Handler) updateNumber(c *fiber.Ctx) error {
 // @Param data body domain.ResetPhoneNumberInput true "phone number confirm"
 // @Success 201 {object} codeResponse
 // @Failure 400,404 {object} response
+// @Failure 429 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /auth/set-phone-number-confirm [post]
@@ -436,9 +719,21 @@ func (h *Handler) updateNumberConfirm(c *fiber.Ctx) error {
 
 	_, id := getUser(c)
 
-	err := h.services.UserAuth.UpdatePhoneNumberConfirm(input, id)
+	err := h.services.UserAuth.UpdatePhoneNumberConfirm(input, id, c.IP())
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditPhoneChange,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   &id,
+		Details:    input.PhoneNumber,
+	}, err)
 
 	if err != nil {
+		var retryErr *domain.RetryAfterError
+		if errors.As(err, &retryErr) {
+			return retryAfterResponse(c, retryErr)
+		}
+
 		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 	}
 
@@ -499,3 +794,97 @@ func (h *Handler) updateUser(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON("OK")
 }
+
+// @Tags auth
+// @Security User_Auth
+// @Description get manager business details and approval status
+// @ModuleID getManagerProfile
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} domain.ManagerProfile
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/manager/profile [get]
+func (h *Handler) getManagerProfile(c *fiber.Ctx) error {
+	_, id := getUser(c)
+
+	profile, err := h.services.UserAuth.GetManagerProfile(id)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(profile)
+}
+
+// @Tags auth
+// @Security User_Auth
+// @Description attach an email to the account and send a verification token to it
+// @ModuleID attachEmail
+// @Accept  json
+// @Produce  json
+// @Param data body domain.AttachEmailInput true "email"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/email [post]
+func (h *Handler) attachEmail(c *fiber.Ctx) error {
+	var inp domain.AttachEmailInput
+
+	if err := c.BodyParser(&inp); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(inp)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	_, id := getUser(c)
+
+	if err := h.services.UserAuth.AttachEmail(id, inp.Email); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Tags auth
+// @Description confirm the email with the token from the verification letter
+// @ModuleID verifyEmail
+// @Accept  json
+// @Produce  json
+// @Param data body domain.VerifyEmailInput true "verification token"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/email/verify [post]
+func (h *Handler) verifyEmail(c *fiber.Ctx) error {
+	var inp domain.VerifyEmailInput
+
+	if err := c.BodyParser(&inp); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(inp)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	if err := h.services.UserAuth.VerifyEmail(inp.Token); err != nil {
+		if errors.Is(err, domain.ErrInvalidEmailToken) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
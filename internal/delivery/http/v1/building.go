@@ -3,9 +3,9 @@ package v1
 import (
 	"carWash/internal/domain"
 	"carWash/pkg/media"
+	"carWash/pkg/validation/validationStructs"
 	"errors"
 	"github.com/gofiber/fiber/v2"
-	jwtware "github.com/gofiber/jwt/v3"
 	"mime/multipart"
 	"strconv"
 )
@@ -19,24 +19,31 @@ type Building struct {
 	WorkTime      int                   `json:"work_time"   form:"work_time" enums:"1,2" examples:"1" `
 	StartTime     int                   `json:"start_time"  form:"start_time"`
 	EndTime       int                   `json:"end_time"    form:"end_time"`
-	Longtitude    string                `json:"longtitude"  form:"longtitude"`
-	Latitude      string                `json:"latitude"    form:"latitude"`
+	Longtitude    *float64              `json:"longtitude"  form:"longtitude" validate:"required_with=Latitude,omitempty,min=-180,max=180"`
+	Latitude      *float64              `json:"latitude"    form:"latitude"   validate:"required_with=Longtitude,omitempty,min=-90,max=90"`
+	Resolution    string                `json:"resolution"  form:"resolution" validate:"omitempty,oneof=keep cancel"`
+	Timezone      string                `json:"timezone"    form:"timezone"   validate:"omitempty,timezone"`
 }
 
 func (h *Handler) initBuildingRoutes(api fiber.Router) {
 	partner := api.Group("/building")
 	{
 		partner.Get("/", h.getAllBuildings)
+		partner.Get("/map", h.getBuildingsMap)
+		partner.Get("/suggest", h.suggestBuildings)
 		partner.Get("/:id", h.getBuildingById)
-		admin := partner.Group("", jwtware.New(
-			jwtware.Config{
-				SigningKey: []byte(h.signingKey),
-			}), isManager)
-		{
-			admin.Post("", h.createBuilding)
-			admin.Put("/:id", h.updateBuilding)
-			admin.Delete("/:id", h.deleteBuilding)
-		}
+		partner.Post("", h.jwtMiddleware(), h.isManager, h.createBuilding)
+		partner.Put("/:id", h.apiKeyOrJWT(), h.requirePermission(domain.PermBuildingEdit, buildingParam("id")), h.updateBuilding)
+		partner.Delete("/:id", h.apiKeyOrJWT(), h.requirePermission(domain.PermBuildingDelete, buildingParam("id")), h.deleteBuilding)
+		partner.Post("/:id/images", h.apiKeyOrJWT(), h.requirePermission(domain.PermBuildingEdit, buildingParam("id")), h.addBuildingImages)
+		partner.Put("/:id/images/order", h.apiKeyOrJWT(), h.requirePermission(domain.PermBuildingEdit, buildingParam("id")), h.orderBuildingImages)
+		partner.Put("/:id/images/:imageId/cover", h.apiKeyOrJWT(), h.requirePermission(domain.PermBuildingEdit, buildingParam("id")), h.setBuildingCover)
+		partner.Delete("/:id/images/:imageId", h.apiKeyOrJWT(), h.requirePermission(domain.PermBuildingEdit, buildingParam("id")), h.deleteBuildingImage)
+		partner.Get("/:id/schedule", h.getBuildingSchedule)
+		partner.Put("/:id/schedule/week", h.apiKeyOrJWT(), h.requirePermission(domain.PermBuildingEdit, buildingParam("id")), h.setWeekSchedule)
+		partner.Put("/:id/schedule/booking", h.apiKeyOrJWT(), h.requirePermission(domain.PermBuildingEdit, buildingParam("id")), h.setBookingRules)
+		partner.Post("/:id/schedule/exceptions", h.apiKeyOrJWT(), h.requirePermission(domain.PermBuildingEdit, buildingParam("id")), h.setScheduleException)
+		partner.Delete("/:id/schedule/exceptions/:exceptionId", h.apiKeyOrJWT(), h.requirePermission(domain.PermBuildingEdit, buildingParam("id")), h.deleteScheduleException)
 	}
 }
 
@@ -53,8 +60,9 @@ func (h *Handler) initBuildingRoutes(api fiber.Router) {
 // @Param work_time formData int true "work time type(1 - always,2 -your own choice)" Enums(1 ,2)
 // @Param start_time formData int true "start of work time"
 // @Param end_time   formData int true "end of work time"
-// @Param longtitude formData string true "longtitude"
-// @Param latitude   formData string true "latitude"
+// @Param longtitude formData number true "longtitude, -180 to 180"
+// @Param latitude   formData number true "latitude, -90 to 90"
+// @Param timezone   formData string false "IANA timezone of the building, Asia/Almaty by default"
 // @Success 201 {object} idResponse
 // @Failure 400,404 {object} response
 // @Failure 500 {object} response
@@ -72,6 +80,16 @@ func (h *Handler) createBuilding(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 	}
 
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	if input.Latitude == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: domain.ErrInvalidCoordinates.Error()})
+	}
+
 	input.BuildingImage, _ = c.FormFile("image")
 	var img string
 
@@ -95,6 +113,7 @@ func (h *Handler) createBuilding(c *fiber.Ctx) error {
 		EndTime:       input.EndTime,
 		Latitude:      input.Latitude,
 		Longtitude:    input.Longtitude,
+		Timezone:      input.Timezone,
 	}
 
 	id, err := h.services.Building.Create(c, building)
@@ -107,7 +126,7 @@ func (h *Handler) createBuilding(c *fiber.Ctx) error {
 }
 
 // @Tags building
-// @Description get all buildings
+// @Description get all buildings, q searches by name, address and description
 // @ID get-all-buildings
 // @Accept  json
 // @Produce  json
@@ -146,6 +165,12 @@ func (h *Handler) getAllBuildings(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
 	}
 
+	ok, errs := validationStructs.ValidateStruct(filter)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
 	list, err := h.services.Building.GetAll(c, page, info, filter)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response{err.Error()})
@@ -154,6 +179,74 @@ func (h *Handler) getAllBuildings(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(list)
 }
 
+// @Tags building
+// @Description get buildings inside the map viewport, below zoom 14 they are grouped into clusters
+// @ID get-buildings-map
+// @Accept  json
+// @Produce  json
+// @Param filter query domain.MapFilter true "viewport bounds and zoom, west is greater than east across the antimeridian"
+// @Success 200 {object} domain.MapResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/map [get]
+func (h *Handler) getBuildingsMap(c *fiber.Ctx) error {
+	var filter domain.MapFilter
+
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(filter)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	if *filter.South > *filter.North {
+		return c.Status(fiber.StatusBadRequest).JSON(response{domain.ErrInvalidViewport.Error()})
+	}
+
+	res, err := h.services.Building.GetMap(c, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(res)
+}
+
+// @Tags building
+// @Description autocomplete of building names and addresses by the typed text
+// @ID suggest-buildings
+// @Accept  json
+// @Produce  json
+// @Param filter query domain.SuggestFilter true "typed text and the number of suggestions, 10 by default"
+// @Success 200 {array} domain.BuildingSuggestion
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/suggest [get]
+func (h *Handler) suggestBuildings(c *fiber.Ctx) error {
+	var filter domain.SuggestFilter
+
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(filter)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	list, err := h.services.Building.Suggest(c, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
 // @Tags building
 // @Description get building by id
 // @ID get-building-by-id
@@ -197,6 +290,7 @@ func (h *Handler) getBuildingById(c *fiber.Ctx) error {
 }
 
 // @Security User_Auth
+// @Security API_Key
 // @Tags building
 // @Description  update  building
 // @ModuleID updateBuilding
@@ -209,12 +303,16 @@ func (h *Handler) getBuildingById(c *fiber.Ctx) error {
 // @Param description formData string false "building description"
 // @Param image formData file false "building image"
 // @Param work_time formData int true "work time type(1 - always,2 -your own choice)" Enums(1 ,2)
-// @Param start_time formData string false "start of work time"
-// @Param end_time   formData string false "end of work time"
-// @Param longtitude formData string false "longtitude"
-// @Param latitude   formData string false "latitude"
-// @Success 200 {object} okResponse
+// @Param start_time formData int false "start of work time, sent together with end_time"
+// @Param end_time   formData int false "end of work time, rebuilds the hours of every weekday"
+// @Param resolution formData string false "what to do with upcoming orders outside the new hours, without it the change is refused with 409" Enums(keep, cancel)
+// @Param longtitude formData number false "longtitude, -180 to 180, sent together with latitude"
+// @Param latitude   formData number false "latitude, -90 to 90, sent together with longtitude"
+// @Param timezone   formData string false "IANA timezone of the building, the hours stay in local time so upcoming orders are checked as for new hours"
+// @Success 200 {object} scheduleResponse
 // @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 409 {object} scheduleResponse
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /building/{id} [put]
@@ -228,13 +326,17 @@ func (h *Handler) updateBuilding(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 	}
 
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 	}
 
-	_, userId := getUser(c)
-
 	input.BuildingImage, _ = c.FormFile("image")
 
 	var img string
@@ -251,26 +353,35 @@ func (h *Handler) updateBuilding(c *fiber.Ctx) error {
 		Name:          input.Name,
 		Address:       input.Address,
 		Instagram:     input.Instagram,
-		ManagerId:     userId,
 		BuildingImage: img,
 		Description:   input.Description,
 		WorkTime:      input.WorkTime,
 		StartTime:     input.StartTime,
 		EndTime:       input.EndTime,
+		Latitude:      input.Latitude,
+		Longtitude:    input.Longtitude,
+		Timezone:      input.Timezone,
 	}
 
-	if err := h.services.Building.Update(c, id, building); err != nil {
+	conflicts, err := h.services.Building.Update(c, id, building, input.Resolution)
+
+	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 		}
 
+		if errors.Is(err, domain.ErrScheduleConflict) || errors.Is(err, domain.ErrInvalidSchedule) {
+			return scheduleErrorResponse(c, err, conflicts)
+		}
+
 		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
 	}
-	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+	return c.Status(fiber.StatusOK).JSON(scheduleResponse{Message: "OK", Conflicts: conflicts})
 
 }
 
 // @Security User_Auth
+// @Security API_Key
 // @Tags building
 // @Description delete building
 // @ModuleID deleteBuilding
@@ -279,6 +390,7 @@ func (h *Handler) updateBuilding(c *fiber.Ctx) error {
 // @Param id path string true "building id"
 // @Success 200 {object} okResponse
 // @Failure 400,404 {object} response
+// @Failure 403 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /building/{id} [delete]
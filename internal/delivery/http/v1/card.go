@@ -5,7 +5,6 @@ import (
 	"carWash/pkg/validation/validationStructs"
 	"errors"
 	"github.com/gofiber/fiber/v2"
-	jwtware "github.com/gofiber/jwt/v3"
 	"strconv"
 )
 
@@ -25,10 +24,7 @@ func (h *Handler) initCardRoutes(api fiber.Router) {
 
 	card := api.Group("/card")
 	{
-		admin := card.Group("", jwtware.New(
-			jwtware.Config{
-				SigningKey: []byte(h.signingKey),
-			}), isUser)
+		admin := card.Group("", h.jwtMiddleware(), isUser)
 		{
 			admin.Get("/", h.getAllCard)
 			admin.Get("/:id", h.getCardById)
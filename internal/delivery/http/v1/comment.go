@@ -4,7 +4,6 @@ import (
 	"carWash/internal/domain"
 	"carWash/pkg/validation/validationStructs"
 	"github.com/gofiber/fiber/v2"
-	jwtware "github.com/gofiber/jwt/v3"
 )
 
 type Comment struct {
@@ -18,10 +17,7 @@ func (h *Handler) initCommentRoutes(api fiber.Router) {
 	partner := api.Group("/comment")
 	{
 		partner.Get("/", h.getAllComments)
-		admin := partner.Group("", jwtware.New(
-			jwtware.Config{
-				SigningKey: []byte(h.signingKey),
-			}), isUser)
+		admin := partner.Group("", h.jwtMiddleware(), isUser)
 		{
 			admin.Post("", h.createComment)
 		}
@@ -6,7 +6,6 @@ import (
 	"carWash/pkg/validation/validationStructs"
 	"errors"
 	"github.com/gofiber/fiber/v2"
-	jwtware "github.com/gofiber/jwt/v3"
 	"strconv"
 )
 
@@ -15,9 +14,7 @@ type Favourite struct {
 }
 
 func (h *Handler) initFavouriteRoutes(api fiber.Router) {
-	router := api.Group("/favourite", jwtware.New(
-		jwtware.Config{
-			SigningKey: []byte(h.signingKey)}), isUser)
+	router := api.Group("/favourite", h.jwtMiddleware(), isUser)
 	{
 		router.Post("", h.createFavourite)
 		router.Get("", h.getAllFavourites)
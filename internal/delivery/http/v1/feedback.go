@@ -3,7 +3,6 @@ package v1
 import (
 	"carWash/internal/domain"
 	"github.com/gofiber/fiber/v2"
-	jwtware "github.com/gofiber/jwt/v3"
 )
 
 type Feedback struct {
@@ -19,7 +18,7 @@ func (h *Handler) initNotificationRoutes(api fiber.Router) {
 
 	partner := api.Group("/notification")
 	{
-		partner.Post("/", h.createNotification)
+		partner.Post("/", h.jwtMiddleware(), h.requirePermission(domain.PermNotificationSend), h.createNotification)
 		partner.Get("/", h.getAllNotifications)
 	}
 }
@@ -30,10 +29,7 @@ func (h *Handler) initFeedbackRoutes(api fiber.Router) {
 	{
 		partner.Get("/", h.getAllFeedbacks)
 
-		admin := partner.Group("", jwtware.New(
-			jwtware.Config{
-				SigningKey: []byte(h.signingKey),
-			}), isUser)
+		admin := partner.Group("", h.jwtMiddleware(), isUser)
 		{
 			admin.Post("", h.createFeedback)
 		}
@@ -113,6 +109,7 @@ func (h *Handler) getAllFeedbacks(c *fiber.Ctx) error {
 // @Param data body Notification true "notification input"
 // @Success 201 {object} idResponse
 // @Failure 400,404 {object} response
+// @Failure 403 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /notification [post]
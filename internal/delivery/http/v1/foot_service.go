@@ -23,12 +23,13 @@ func (h *Handler) initFootServiceRoutes(api fiber.Router) {
 	{
 		partner.Get("/", h.getAllFootService)
 		partner.Get("/:id", h.getFootServiceById)
-		partner.Post("", h.createFootService)
-		partner.Put("/:id", h.updateFootService)
-		partner.Delete("/:id", h.deleteFootService)
+		partner.Post("", h.jwtMiddleware(), h.requirePermission(domain.PermServiceManage), h.createFootService)
+		partner.Put("/:id", h.jwtMiddleware(), h.requirePermission(domain.PermServiceManage), h.updateFootService)
+		partner.Delete("/:id", h.jwtMiddleware(), h.requirePermission(domain.PermServiceManage), h.deleteFootService)
 	}
 }
 
+// @Security User_Auth
 // @Tags service
 // @ModuleID createFootService
 // @Accept  json
@@ -36,6 +37,7 @@ func (h *Handler) initFootServiceRoutes(api fiber.Router) {
 // @Param data body FootService true "service input"
 // @Success 201 {object} idResponse
 // @Failure 400,404 {object} response
+// @Failure 403 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /service [post]
@@ -125,6 +127,7 @@ func (h *Handler) getFootServiceById(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(list)
 }
 
+// @Security User_Auth
 // @Tags service
 // @Description  update  service
 // @ModuleID updateFootService
@@ -134,6 +137,7 @@ func (h *Handler) getFootServiceById(c *fiber.Ctx) error {
 // @Param data body UpdateFootService true "foot service input"
 // @Success 200 {object} okResponse
 // @Failure 400,404 {object} response
+// @Failure 403 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /service/{id} [put]
@@ -165,6 +169,7 @@ func (h *Handler) updateFootService(c *fiber.Ctx) error {
 
 }
 
+// @Security User_Auth
 // @Tags service
 // @Description delete service
 // @ModuleID deleteFootService
@@ -173,6 +178,7 @@ func (h *Handler) updateFootService(c *fiber.Ctx) error {
 // @Param id path string true "service id"
 // @Success 200 {object} okResponse
 // @Failure 400,404 {object} response
+// @Failure 403 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /service/{id} [delete]
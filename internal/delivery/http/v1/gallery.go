@@ -0,0 +1,281 @@
+package v1
+
+import (
+	"carWash/internal/domain"
+	"carWash/pkg/media"
+	"carWash/pkg/validation/validationStructs"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+// galleryErrorResponse maps the errors shared by the gallery endpoints of buildings and pitches.
+func galleryErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+	case errors.Is(err, domain.ErrCoverImage),
+		errors.Is(err, domain.ErrTooManyImages),
+		errors.Is(err, domain.ErrImageOrder):
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+}
+
+func imageOwner(c *fiber.Ctx, ownerType string) (domain.ImageOwner, error) {
+	id, err := strconv.Atoi(c.Params("id"))
+
+	return domain.ImageOwner{Type: ownerType, Id: id}, err
+}
+
+func (h *Handler) addImages(c *fiber.Ctx, ownerType string) error {
+	owner, err := imageOwner(c, ownerType)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	files := form.File["images"]
+
+	if len(files) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: "no images"})
+	}
+
+	images := make([]string, 0, len(files))
+
+	for _, file := range files {
+		img, err := media.GetFileName(c, file)
+		if err != nil {
+			for _, saved := range images {
+				_ = media.DeleteImage(saved)
+			}
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+
+		images = append(images, img)
+	}
+
+	added, err := h.services.Gallery.AddImages(c, owner, images)
+	if err != nil {
+		return galleryErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(added)
+}
+
+func (h *Handler) orderImages(c *fiber.Ctx, ownerType string) error {
+	var input domain.ImageOrderInput
+
+	owner, err := imageOwner(c, ownerType)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err = c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	if err = h.services.Gallery.SetImagesOrder(c, owner, input.Ids); err != nil {
+		return galleryErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+func (h *Handler) setCover(c *fiber.Ctx, ownerType string) error {
+	owner, err := imageOwner(c, ownerType)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	imageId, err := strconv.Atoi(c.Params("imageId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err = h.services.Gallery.SetCover(c, owner, imageId); err != nil {
+		return galleryErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+func (h *Handler) deleteImage(c *fiber.Ctx, ownerType string) error {
+	owner, err := imageOwner(c, ownerType)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	imageId, err := strconv.Atoi(c.Params("imageId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err = h.services.Gallery.DeleteImage(c, owner, imageId); err != nil {
+		return galleryErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags building
+// @Description add images to the building gallery, the first image of an empty gallery becomes the cover
+// @ModuleID addBuildingImages
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param id path int true "building id"
+// @Param images formData file true "images, the field may be repeated"
+// @Success 201 {array} domain.Image
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/images [post]
+func (h *Handler) addBuildingImages(c *fiber.Ctx) error {
+	return h.addImages(c, domain.ImageOwnerBuilding)
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags building
+// @Description reorder the building gallery, ids of all its images in the new order
+// @ModuleID orderBuildingImages
+// @Accept  json
+// @Produce  json
+// @Param id path int true "building id"
+// @Param input body domain.ImageOrderInput true "image ids"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/images/order [put]
+func (h *Handler) orderBuildingImages(c *fiber.Ctx) error {
+	return h.orderImages(c, domain.ImageOwnerBuilding)
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags building
+// @Description make the image the cover of the building
+// @ModuleID setBuildingCover
+// @Accept  json
+// @Produce  json
+// @Param id path int true "building id"
+// @Param imageId path int true "image id"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/images/{imageId}/cover [put]
+func (h *Handler) setBuildingCover(c *fiber.Ctx) error {
+	return h.setCover(c, domain.ImageOwnerBuilding)
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags building
+// @Description delete the image from the building gallery, the cover can not be deleted
+// @ModuleID deleteBuildingImage
+// @Accept  json
+// @Produce  json
+// @Param id path int true "building id"
+// @Param imageId path int true "image id"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/images/{imageId} [delete]
+func (h *Handler) deleteBuildingImage(c *fiber.Ctx) error {
+	return h.deleteImage(c, domain.ImageOwnerBuilding)
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags pitch
+// @Description add images to the pitch gallery, the first image of an empty gallery becomes the cover
+// @ModuleID addPitchImages
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param id path int true "pitch id"
+// @Param images formData file true "images, the field may be repeated"
+// @Success 201 {array} domain.Image
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /pitch/{id}/images [post]
+func (h *Handler) addPitchImages(c *fiber.Ctx) error {
+	return h.addImages(c, domain.ImageOwnerPitch)
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags pitch
+// @Description reorder the pitch gallery, ids of all its images in the new order
+// @ModuleID orderPitchImages
+// @Accept  json
+// @Produce  json
+// @Param id path int true "pitch id"
+// @Param input body domain.ImageOrderInput true "image ids"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /pitch/{id}/images/order [put]
+func (h *Handler) orderPitchImages(c *fiber.Ctx) error {
+	return h.orderImages(c, domain.ImageOwnerPitch)
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags pitch
+// @Description make the image the cover of the pitch
+// @ModuleID setPitchCover
+// @Accept  json
+// @Produce  json
+// @Param id path int true "pitch id"
+// @Param imageId path int true "image id"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /pitch/{id}/images/{imageId}/cover [put]
+func (h *Handler) setPitchCover(c *fiber.Ctx) error {
+	return h.setCover(c, domain.ImageOwnerPitch)
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags pitch
+// @Description delete the image from the pitch gallery, the cover can not be deleted
+// @ModuleID deletePitchImage
+// @Accept  json
+// @Produce  json
+// @Param id path int true "pitch id"
+// @Param imageId path int true "image id"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /pitch/{id}/images/{imageId} [delete]
+func (h *Handler) deletePitchImage(c *fiber.Ctx) error {
+	return h.deleteImage(c, domain.ImageOwnerPitch)
+}
@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"carWash/internal/domain"
+	"carWash/pkg/validation/validationStructs"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+)
+
+type guestTokenResponse struct {
+	AccessToken string `json:"access"`
+}
+
+func (h *Handler) initGuestRoutes(auth fiber.Router) {
+	auth.Post("guest/code", h.guestSendCode)
+	auth.Post("guest/verify", h.guestVerify)
+	auth.Post("guest/claim", h.jwtMiddleware(), isGuest, h.claimGuest)
+}
+
+// @Tags auth
+// @Description send a code for guest checkout, phones of registered accounts have to sign in
+// @ModuleID guestSendCode
+// @Accept json
+// @Produce json
+// @Param data body domain.GuestCodeInput true "guest phone"
+// @Success 201 {object} codeResponse
+// @Failure 400,404 {object} response
+// @Failure 429 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/guest/code [post]
+func (h *Handler) guestSendCode(c *fiber.Ctx) error {
+	var input domain.GuestCodeInput
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	secret, err := h.services.UserAuth.GuestSendCode(input.PhoneNumber, c.Get(fiber.HeaderAcceptLanguage))
+
+	if err != nil {
+		var retryErr *domain.RetryAfterError
+		if errors.As(err, &retryErr) {
+			return retryAfterResponse(c, retryErr)
+		}
+
+		if errors.Is(err, domain.ErrUserAlreadyExist) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(h.newCodeResponse(secret))
+}
+
+// @Tags auth
+// @Description verify the guest phone, the returned token can only book and list own orders
+// @ModuleID guestVerify
+// @Accept json
+// @Produce json
+// @Param data body domain.GuestVerifyInput true "guest verify"
+// @Success 200 {object} guestTokenResponse
+// @Failure 400,404 {object} response
+// @Failure 429 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/guest/verify [post]
+func (h *Handler) guestVerify(c *fiber.Ctx) error {
+	var input domain.GuestVerifyInput
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	device := domain.Device{
+		Name:      input.DeviceName,
+		Ip:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}
+
+	res, err := h.services.UserAuth.GuestVerify(input, device, c.IP())
+
+	if err != nil {
+		var retryErr *domain.RetryAfterError
+		if errors.As(err, &retryErr) {
+			return retryAfterResponse(c, retryErr)
+		}
+
+		if errors.Is(err, domain.ErrInvalidSecretCode) || errors.Is(err, domain.ErrUserAlreadyExist) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(guestTokenResponse{AccessToken: res.AccessToken})
+}
+
+// @Tags auth
+// @Security User_Auth
+// @Description turn the guest account into a full one by setting a password, orders are kept
+// @ModuleID claimGuest
+// @Accept json
+// @Produce json
+// @Param data body domain.ClaimGuestInput true "password for the account"
+// @Success 200 {object} tokenResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/guest/claim [post]
+func (h *Handler) claimGuest(c *fiber.Ctx) error {
+	var input domain.ClaimGuestInput
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	_, id := getUser(c)
+
+	device := domain.Device{
+		Name:      input.DeviceName,
+		Ip:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}
+
+	res, err := h.services.UserAuth.ClaimGuest(id, getSessionId(c), input, device)
+
+	h.audit(c, domain.AuditEntry{Action: domain.AuditGuestClaim, TargetType: domain.AuditTargetUser, TargetId: &id}, err)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrPasswordNotMatch) || errors.Is(err, domain.ErrUserAlreadyExist) {
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(tokenResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+	})
+}
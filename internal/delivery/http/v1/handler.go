@@ -1,8 +1,12 @@
 package v1
 
 import (
+	"carWash/internal/config"
+	"carWash/internal/domain"
 	"carWash/internal/service"
 	"carWash/pkg/auth"
+	"carWash/pkg/ratelimit"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v4"
 	"strconv"
@@ -16,15 +20,24 @@ const (
 type Handler struct {
 	services     *service.Service
 	tokenManager auth.TokenManager
-	signingKey   string
+	environment  string
+	limiter      *ratelimit.Limiter
+	rateLimit    config.RateLimitConfig
 }
 
-func NewHandler(services *service.Service, tokenManager auth.TokenManager, signingKey string) *Handler {
-	return &Handler{services: services, tokenManager: tokenManager, signingKey: signingKey}
+func NewHandler(services *service.Service, tokenManager auth.TokenManager, environment string,
+	limiter *ratelimit.Limiter, rateLimit config.RateLimitConfig) *Handler {
+	return &Handler{
+		services:     services,
+		tokenManager: tokenManager,
+		environment:  environment,
+		limiter:      limiter,
+		rateLimit:    rateLimit,
+	}
 }
 
 func (h *Handler) Init(api fiber.Router) {
-	v1 := api.Group("/v1")
+	v1 := api.Group("/v1", h.rateLimitMiddleware())
 	{
 		h.initNotificationRoutes(v1)
 		h.initFootServiceRoutes(v1)
@@ -36,11 +49,17 @@ func (h *Handler) Init(api fiber.Router) {
 		h.initCommentRoutes(v1)
 		h.initFeedbackRoutes(v1)
 		h.initCardRoutes(v1)
+		h.initAdminRoutes(v1)
+		h.initAccessRoutes(v1)
 	}
 }
 
 func getUser(c *fiber.Ctx) (string, int) {
 
+	if apiKey, ok := c.Locals(apiKeyCtx).(*domain.APIKey); ok {
+		return apiKey.UserType, apiKey.UserId
+	}
+
 	user := c.Locals("user").(*jwt.Token)
 
 	claims := user.Claims.(jwt.MapClaims)
@@ -57,12 +76,43 @@ func getUser(c *fiber.Ctx) (string, int) {
 	return userType, idInt
 }
 
-func isAdmin(c *fiber.Ctx) error {
-	userType, _ := getUser(c)
+func getSessionId(c *fiber.Ctx) int {
+
+	user := c.Locals("user").(*jwt.Token)
+
+	claims := user.Claims.(jwt.MapClaims)
+
+	sid, ok := claims["sid"].(string)
+
+	if !ok {
+		return 0
+	}
+
+	sessionId, err := strconv.Atoi(sid)
+
+	if err != nil {
+		return 0
+	}
+	return sessionId
+}
+
+// isAdmin also requires 2FA, admins without it can only reach the enrollment endpoints.
+func (h *Handler) isAdmin(c *fiber.Ctx) error {
+	userType, id := getUser(c)
 
 	if userType != "admin" {
 		return c.Status(fiber.StatusUnauthorized).JSON(response{Message: "нет доступа"})
 	}
+
+	enabled, err := h.services.TwoFactor.IsTwoFactorEnabled(id)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	if !enabled {
+		return c.Status(fiber.StatusForbidden).JSON(response{Message: domain.ErrTwoFactorRequired.Error()})
+	}
 	return c.Next()
 }
 
@@ -75,7 +125,35 @@ func isUser(c *fiber.Ctx) error {
 	return c.Next()
 }
 
-func isManager(c *fiber.Ctx) error {
+func isGuest(c *fiber.Ctx) error {
+	userType, _ := getUser(c)
+
+	if userType != guest {
+		return c.Status(fiber.StatusUnauthorized).JSON(response{Message: "нет доступа"})
+	}
+	return c.Next()
+}
+
+func isUserOrGuest(c *fiber.Ctx) error {
+	userType, _ := getUser(c)
+
+	if userType != user && userType != guest {
+		return c.Status(fiber.StatusUnauthorized).JSON(response{Message: "нет доступа"})
+	}
+	return c.Next()
+}
+
+func isManagerOrAdmin(c *fiber.Ctx) error {
+	userType, _ := getUser(c)
+
+	if userType != "manager" && userType != "admin" {
+		return c.Status(fiber.StatusUnauthorized).JSON(response{Message: "нет доступа"})
+	}
+	return c.Next()
+}
+
+// isManagerAccount only checks the account type, pending managers pass it too.
+func isManagerAccount(c *fiber.Ctx) error {
 	userType, _ := getUser(c)
 
 	if userType != "manager" {
@@ -83,3 +161,22 @@ func isManager(c *fiber.Ctx) error {
 	}
 	return c.Next()
 }
+
+func (h *Handler) isManager(c *fiber.Ctx) error {
+	userType, id := getUser(c)
+
+	if userType != "manager" {
+		return c.Status(fiber.StatusUnauthorized).JSON(response{Message: "нет доступа"})
+	}
+
+	profile, err := h.services.UserAuth.GetManagerProfile(id)
+
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	if err != nil || profile.Status != domain.ManagerApproved {
+		return c.Status(fiber.StatusForbidden).JSON(response{Message: domain.ErrManagerNotApproved.Error()})
+	}
+	return c.Next()
+}
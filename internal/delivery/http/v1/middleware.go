@@ -1,7 +1,11 @@
 package v1
 
 import (
+	"carWash/internal/domain"
+	"errors"
 	"fmt"
+	"github.com/gofiber/fiber/v2"
+	jwtware "github.com/gofiber/jwt/v3"
 	"strconv"
 	"strings"
 )
@@ -11,6 +15,8 @@ const (
 	userCtx             = "userId"
 	userType            = "userType"
 	adminCtx            = "adminId"
+	apiKeyHeader        = "X-API-Key"
+	apiKeyCtx           = "apiKey"
 )
 
 func (h *Handler) userIdentity(header string) (int, string, error) {
@@ -38,3 +44,59 @@ func (h *Handler) userIdentity(header string) (int, string, error) {
 	return userId, userType, nil
 
 }
+
+func (h *Handler) jwtMiddleware() fiber.Handler {
+	return jwtware.New(jwtware.Config{
+		KeyFunc:        h.tokenManager.Keyfunc,
+		SuccessHandler: h.sessionIdentity,
+	})
+}
+
+// apiKeyOrJWT lets integrations call the route with X-API-Key instead of an access token,
+// what the key may do is checked by requirePermission.
+func (h *Handler) apiKeyOrJWT() fiber.Handler {
+	jwt := h.jwtMiddleware()
+
+	return func(c *fiber.Ctx) error {
+		key := c.Get(apiKeyHeader)
+
+		if key == "" {
+			return jwt(c)
+		}
+
+		apiKey, err := h.services.APIKey.Authenticate(key, c.IP())
+
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidAPIKey) {
+				return c.Status(fiber.StatusUnauthorized).JSON(response{Message: err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+		}
+
+		c.Locals(apiKeyCtx, apiKey)
+
+		return c.Next()
+	}
+}
+
+// sessionIdentity rejects access tokens whose session has been revoked,
+// tokens issued before sessions were bound to them are rejected as well.
+func (h *Handler) sessionIdentity(c *fiber.Ctx) error {
+	sessionId := getSessionId(c)
+
+	if sessionId == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(response{Message: domain.ErrSessionRevoked.Error()})
+	}
+
+	revoked, err := h.services.UserAuth.IsSessionRevoked(sessionId)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	if revoked {
+		return c.Status(fiber.StatusUnauthorized).JSON(response{Message: domain.ErrSessionRevoked.Error()})
+	}
+
+	return c.Next()
+}
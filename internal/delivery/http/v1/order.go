@@ -4,8 +4,8 @@ import (
 	"carWash/internal/domain"
 	"carWash/pkg/validation/validationStructs"
 	"errors"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
-	jwtware "github.com/gofiber/jwt/v3"
 	"strconv"
 )
 
@@ -14,26 +14,27 @@ func (h *Handler) initOrderRoutes(api fiber.Router) {
 	{
 		order.Get("/", h.getAllOrders)
 		order.Get("/times", h.getOrderForCreateOrder)
+		order.Post("/", h.jwtMiddleware(), isUserOrGuest, h.createOrder)
+		order.Post("/pitch/:id/block", h.apiKeyOrJWT(), h.requirePermission(domain.PermOrderBlock, h.pitchBuilding("id")), h.createOrderBlock)
 
-		admin := order.Group("/", jwtware.New(
-			jwtware.Config{
-				SigningKey: []byte(h.signingKey)}), isUser)
+		admin := order.Group("/", h.jwtMiddleware(), isUser)
 		{
-			admin.Post("/", h.createOrder)
 			admin.Delete("/:id", h.deleteOrder)
 		}
 	}
 }
 
 type order struct {
-	PitchId     int      `json:"pitch_id"   validate:"required"`
-	OrderDate   float64  `json:"order_date" validate:"required"`
-	Times       []string `json:"times" validate:"required"`
-	ServiceIds  []int    `json:"service_ids"`
-	CardId      int      `json:"card_id"`
-	FirstName   string   `json:"first_name"`
-	PhoneNumber string   `json:"phone_number" validate:"required"`
-	ExtraInfo   string   `json:"extra_info"`
+	PitchId     int     `json:"pitch_id"   validate:"required"`
+	Date        string  `json:"date" validate:"required_without=OrderDate,omitempty,datetime=2006-01-02" example:"2024-05-20"`
+	OrderDate   float64 `json:"order_date" validate:"required_without=Date"`
+	StartTime   string  `json:"start_time" validate:"required" example:"18:00"`
+	EndTime     string  `json:"end_time" validate:"required" example:"19:30"`
+	ServiceIds  []int   `json:"service_ids"`
+	CardId      int     `json:"card_id"`
+	FirstName   string  `json:"first_name"`
+	PhoneNumber string  `json:"phone_number" validate:"required"`
+	ExtraInfo   string  `json:"extra_info"`
 }
 
 // @Security User_Auth
@@ -41,9 +42,12 @@ type order struct {
 // @ModuleID createOrder
 // @Accept json
 // @Produce  json
+// @Description date is the business day, order_date in unix seconds is still accepted instead of it.
+// @Description start_time and end_time are 15:04 in the local time of the building, the order takes whole slots of the pitch within the opening hours
 // @Param data body order true "order create input"
 // @Success 201 {object} idResponse
 // @Failure 400,404 {object} response
+// @Failure 409 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /order [post]
@@ -63,13 +67,24 @@ func (h *Handler) createOrder(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(mess)
 	}
 
-	_, userId := getUser(c)
+	userType, userId := getUser(c)
+
+	// a guest books with the phone verified for the guest token
+	if userType == guest {
+		account, err := h.services.UserAuth.GetUserInfo(userId)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+		}
+		inp.PhoneNumber = account.PhoneNumber
+	}
 
 	input := domain.Order{
 		PitchId:     inp.PitchId,
 		UserId:      userId,
+		Date:        inp.Date,
 		OrderDate:   inp.OrderDate,
-		Times:       inp.Times,
+		StartTime:   inp.StartTime,
+		EndTime:     inp.EndTime,
 		ServiceIds:  inp.ServiceIds,
 		CardId:      inp.CardId,
 		UserName:    inp.FirstName,
@@ -81,6 +96,14 @@ func (h *Handler) createOrder(c *fiber.Ctx) error {
 	id, err := h.services.Order.Create(c, input)
 
 	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		case errors.Is(err, domain.ErrInvalidBookingTime), errors.Is(err, domain.ErrBookingDuration):
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		case errors.Is(err, domain.ErrTimeBooked):
+			return c.Status(fiber.StatusConflict).JSON(response{Message: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
 	}
 
@@ -88,6 +111,89 @@ func (h *Handler) createOrder(c *fiber.Ctx) error {
 
 }
 
+type orderBlock struct {
+	Date        string `json:"date" validate:"required,datetime=2006-01-02" example:"2024-05-20"`
+	StartTime   string `json:"start_time" validate:"required" example:"18:00"`
+	EndTime     string `json:"end_time" validate:"required" example:"19:30"`
+	FirstName   string `json:"first_name"`
+	PhoneNumber string `json:"phone_number"`
+	ExtraInfo   string `json:"extra_info"`
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags orders
+// @ModuleID createOrderBlock
+// @Accept json
+// @Produce  json
+// @Description blocks times of the pitch for a booking taken by the venue or an integration, no confirmation is sent.
+// @Description date is the business day, start_time and end_time are 15:04 in the local time of the building and take whole slots of the pitch within the opening hours
+// @Param id path string true "pitch id"
+// @Param data body orderBlock true "order block input"
+// @Success 201 {object} idResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /order/pitch/{id}/block [post]
+func (h *Handler) createOrderBlock(c *fiber.Ctx) error {
+	var inp orderBlock
+
+	pitchId, err := strconv.Atoi(c.Params("id"))
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err := c.BodyParser(&inp); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, mess := validationStructs.ValidateStruct(inp)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(mess)
+	}
+
+	_, userId := getUser(c)
+
+	input := domain.Order{
+		PitchId:     pitchId,
+		UserId:      userId,
+		Date:        inp.Date,
+		StartTime:   inp.StartTime,
+		EndTime:     inp.EndTime,
+		UserName:    inp.FirstName,
+		PhoneNumber: inp.PhoneNumber,
+		ExtraInfo:   inp.ExtraInfo,
+		Status:      reserved,
+	}
+
+	id, err := h.services.Order.Block(c, input)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditOrderBlock,
+		TargetType: domain.AuditTargetPitch,
+		TargetId:   &pitchId,
+		Details:    fmt.Sprintf("%s %s-%s", inp.Date, inp.StartTime, inp.EndTime),
+	}, err)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		case errors.Is(err, domain.ErrInvalidBookingTime), errors.Is(err, domain.ErrBookingDuration):
+			return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+		case errors.Is(err, domain.ErrTimeBooked):
+			return c.Status(fiber.StatusConflict).JSON(response{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(idResponse{ID: id})
+}
+
 // @Tags orders
 // @Description gets all orders
 // @ID get-all-orders
@@ -114,6 +220,12 @@ func (h *Handler) getAllOrders(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 	}
 
+	ok, errs := validationStructs.ValidateStruct(filter)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
 	header := string(c.Request().Header.Peek("Authorization"))
 
 	userId, userType, err := h.userIdentity(header)
@@ -137,7 +249,7 @@ func (h *Handler) getAllOrders(c *fiber.Ctx) error {
 }
 
 // @Tags orders
-// @Description gets all orders time
+// @Description gets the slots of the order date from the building schedule, booked ones are marked when pitch_id is given
 // @ID get-all-order-times
 // @Accept  json
 // @Produce  json
@@ -157,9 +269,18 @@ func (h *Handler) getOrderForCreateOrder(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 	}
 
+	ok, errs := validationStructs.ValidateStruct(filter)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
 	list, err := h.services.Order.GetAllBookTime(c, filter)
 
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response{err.Error()})
 	}
 
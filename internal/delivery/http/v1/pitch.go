@@ -5,7 +5,6 @@ import (
 	"carWash/pkg/media"
 	"errors"
 	"github.com/gofiber/fiber/v2"
-	jwtware "github.com/gofiber/jwt/v3"
 	"strconv"
 )
 
@@ -27,20 +26,20 @@ func (h *Handler) initPitchRoutes(api fiber.Router) {
 	{
 		partner.Get("/", h.getAllPitch)
 		partner.Get("/:id", h.getPitchById)
-		admin := partner.Group("", jwtware.New(
-			jwtware.Config{
-				SigningKey: []byte(h.signingKey),
-			}), isManager)
-		{
-			admin.Post("", h.createPitch)
-			admin.Put("/:id", h.updatePitch)
-			admin.Delete("/:id", h.deletePitch)
-		}
+		partner.Post("", h.apiKeyOrJWT(), h.requirePermission(domain.PermPitchManage, buildingForm("building_id")), h.createPitch)
+		partner.Put("/:id", h.apiKeyOrJWT(), h.requirePermission(domain.PermPitchManage, h.pitchBuilding("id"), buildingForm("building_id")), h.updatePitch)
+		partner.Delete("/:id", h.apiKeyOrJWT(), h.requirePermission(domain.PermPitchManage, h.pitchBuilding("id")), h.deletePitch)
+		partner.Put("/:id/booking", h.apiKeyOrJWT(), h.requirePermission(domain.PermPitchManage, h.pitchBuilding("id")), h.setPitchBookingRules)
+		partner.Post("/:id/images", h.apiKeyOrJWT(), h.requirePermission(domain.PermPitchManage, h.pitchBuilding("id")), h.addPitchImages)
+		partner.Put("/:id/images/order", h.apiKeyOrJWT(), h.requirePermission(domain.PermPitchManage, h.pitchBuilding("id")), h.orderPitchImages)
+		partner.Put("/:id/images/:imageId/cover", h.apiKeyOrJWT(), h.requirePermission(domain.PermPitchManage, h.pitchBuilding("id")), h.setPitchCover)
+		partner.Delete("/:id/images/:imageId", h.apiKeyOrJWT(), h.requirePermission(domain.PermPitchManage, h.pitchBuilding("id")), h.deletePitchImage)
 	}
 
 }
 
 // @Security User_Auth
+// @Security API_Key
 // @Tags pitch
 // @ModuleID createPitch
 // @Accept  multipart/form-data
@@ -52,6 +51,7 @@ func (h *Handler) initPitchRoutes(api fiber.Router) {
 // @Param pitch_extra formData int false "pitch extra" Enums(1,2)
 // @Success 201 {object} idResponse
 // @Failure 400,404 {object} response
+// @Failure 403 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /pitch [post]
@@ -65,8 +65,6 @@ func (h *Handler) createPitch(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
 	}
 
-	_, userId := getUser(c)
-
 	file, _ := c.FormFile("image")
 	var img string
 
@@ -83,7 +81,6 @@ func (h *Handler) createPitch(c *fiber.Ctx) error {
 		Price:      input.Price,
 		PitchType:  input.PitchType,
 		PitchExtra: input.PitchExtra,
-		ManagerId:  userId,
 	}
 
 	id, err := h.services.Pitch.Create(c, pitch)
@@ -158,6 +155,7 @@ func (h *Handler) getPitchById(c *fiber.Ctx) error {
 }
 
 // @Security User_Auth
+// @Security API_Key
 // @Tags pitch
 // @Description  update  pitch
 // @ModuleID updatePitch
@@ -171,6 +169,7 @@ func (h *Handler) getPitchById(c *fiber.Ctx) error {
 // @Param pitch_extra formData int false "pitch extra" Enums(1,2)
 // @Success 200 {object} okResponse
 // @Failure 400,404 {object} response
+// @Failure 403 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /pitch/{id} [put]
@@ -219,6 +218,7 @@ func (h *Handler) updatePitch(c *fiber.Ctx) error {
 }
 
 // @Security User_Auth
+// @Security API_Key
 // @Tags pitch
 // @Description delete pitch
 // @ModuleID deleteCemetery
@@ -227,6 +227,7 @@ func (h *Handler) updatePitch(c *fiber.Ctx) error {
 // @Param id path string true "pitch id"
 // @Success 200 {object} okResponse
 // @Failure 400,404 {object} response
+// @Failure 403 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /pitch/{id} [delete]
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"carWash/internal/config"
+	"carWash/internal/domain"
+	"carWash/pkg/logger"
+	"carWash/pkg/ratelimit"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"math"
+	"strconv"
+	"strings"
+)
+
+func (h *Handler) rateLimitMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !h.rateLimit.Enabled || h.limiter == nil {
+			return c.Next()
+		}
+
+		policy := h.routePolicy(c.Method(), c.Path())
+
+		key := fmt.Sprintf("%s:%s", policy.Name, h.rateLimitSubject(c))
+
+		result, err := h.limiter.Allow(c.Context(), key, ratelimit.Policy{Limit: policy.Limit, Window: policy.Window})
+
+		if err != nil {
+			// the limiter must not take the API down with redis
+			logger.Error(err)
+			return c.Next()
+		}
+
+		c.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(result.Reset.Seconds()))))
+
+		if !result.Allowed {
+			return retryAfterResponse(c, &domain.RetryAfterError{Err: domain.ErrRateLimited, RetryAfter: result.Reset})
+		}
+
+		return c.Next()
+	}
+}
+
+// routePolicy returns the first configured policy matching the request, or the default one.
+func (h *Handler) routePolicy(method, path string) config.RateLimitPolicy {
+	for _, policy := range h.rateLimit.Routes {
+		if policy.Method != "" && !strings.EqualFold(policy.Method, method) {
+			continue
+		}
+
+		if strings.HasPrefix(path, policy.Prefix) {
+			return policy
+		}
+	}
+
+	return h.rateLimit.Default
+}
+
+// rateLimitSubject keys authenticated calls by user id and anonymous calls by ip.
+func (h *Handler) rateLimitSubject(c *fiber.Ctx) string {
+	header := c.Get(authorizationHeader)
+
+	if strings.HasPrefix(header, "Bearer ") {
+		id, _, err := h.tokenManager.Parse(strings.TrimPrefix(header, "Bearer "))
+
+		if err == nil {
+			return "user:" + id
+		}
+	}
+
+	return "ip:" + c.IP()
+}
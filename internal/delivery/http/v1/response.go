@@ -1,14 +1,18 @@
 package v1
 
 import (
+	"carWash/internal/domain"
 	"carWash/pkg/logger"
 	"github.com/gofiber/fiber/v2"
+	"math"
+	"strconv"
 )
 
 const (
 	user    = "user"
 	admin   = "admin"
 	manager = "manager"
+	guest   = "guest"
 )
 
 type idResponse struct {
@@ -26,3 +30,15 @@ func newResponse(c *fiber.Ctx, statusCode, message string) {
 	logger.Error(message)
 
 }
+
+// retryAfterResponse answers 429 and tells the client in seconds when it may try again.
+func retryAfterResponse(c *fiber.Ctx, err *domain.RetryAfterError) error {
+	seconds := int(math.Ceil(err.RetryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+
+	return c.Status(fiber.StatusTooManyRequests).JSON(response{Message: err.Error()})
+}
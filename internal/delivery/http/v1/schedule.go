@@ -0,0 +1,253 @@
+package v1
+
+import (
+	"carWash/internal/domain"
+	"carWash/pkg/validation/validationStructs"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+// scheduleResponse lists the orders left outside the new hours, on 409 they kept the change
+// from being applied.
+type scheduleResponse struct {
+	Message   string                     `json:"message"`
+	Id        int                        `json:"id,omitempty"`
+	Conflicts []*domain.ScheduleConflict `json:"conflicts,omitempty"`
+}
+
+func scheduleErrorResponse(c *fiber.Ctx, err error, conflicts []*domain.ScheduleConflict) error {
+	switch {
+	case errors.Is(err, domain.ErrScheduleConflict):
+		return c.Status(fiber.StatusConflict).JSON(scheduleResponse{Message: err.Error(), Conflicts: conflicts})
+	case errors.Is(err, domain.ErrNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(response{Message: err.Error()})
+	case errors.Is(err, domain.ErrInvalidSchedule), errors.Is(err, domain.ErrInvalidBookingRules):
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+}
+
+// @Tags building
+// @Description get weekly opening hours and the upcoming exceptions of the building
+// @ID get-building-schedule
+// @Accept  json
+// @Produce  json
+// @Param id path int true "building id"
+// @Success 200 {object} domain.BuildingSchedule
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/schedule [get]
+func (h *Handler) getBuildingSchedule(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	schedule, err := h.services.Schedule.GetSchedule(id)
+	if err != nil {
+		return scheduleErrorResponse(c, err, nil)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(schedule)
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags building
+// @Description set opening hours of weekdays, 1 is Monday and 7 is Sunday, days left out keep their hours.
+// @Description Upcoming orders outside the new hours are kept or cancelled with an SMS by the resolution, without it the change is refused with 409
+// @ModuleID setWeekSchedule
+// @Accept  json
+// @Produce  json
+// @Param id path int true "building id"
+// @Param input body domain.WeekScheduleInput true "hours as 15:04, close time may be 24:00, a close time before the open time is after midnight"
+// @Success 200 {object} scheduleResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 409 {object} scheduleResponse
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/schedule/week [put]
+func (h *Handler) setWeekSchedule(c *fiber.Ctx) error {
+	var input domain.WeekScheduleInput
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err = c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	conflicts, err := h.services.Schedule.SetWeek(id, input.Days, input.Resolution)
+	if err != nil {
+		return scheduleErrorResponse(c, err, conflicts)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(scheduleResponse{Message: "OK", Conflicts: conflicts})
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags building
+// @Description close the building on a date or set shortened hours, an exception on the same date is replaced.
+// @Description Orders that do not fit are handled by the resolution as for the weekly hours
+// @ModuleID setScheduleException
+// @Accept  json
+// @Produce  json
+// @Param id path int true "building id"
+// @Param input body domain.ScheduleExceptionInput true "date as 2006-01-02, hours are required unless the building is closed"
+// @Success 200 {object} scheduleResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 409 {object} scheduleResponse
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/schedule/exceptions [post]
+func (h *Handler) setScheduleException(c *fiber.Ctx) error {
+	var input domain.ScheduleExceptionInput
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err = c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	exceptionId, conflicts, err := h.services.Schedule.SetException(id, input.ScheduleException, input.Resolution)
+	if err != nil {
+		return scheduleErrorResponse(c, err, conflicts)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(scheduleResponse{Message: "OK", Id: exceptionId, Conflicts: conflicts})
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags building
+// @Description delete the schedule exception, the weekly hours apply again
+// @ModuleID deleteScheduleException
+// @Accept  json
+// @Produce  json
+// @Param id path int true "building id"
+// @Param exceptionId path int true "exception id"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/schedule/exceptions/{exceptionId} [delete]
+func (h *Handler) deleteScheduleException(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	exceptionId, err := strconv.Atoi(c.Params("exceptionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err = h.services.Schedule.DeleteException(id, exceptionId); err != nil {
+		return scheduleErrorResponse(c, err, nil)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags building
+// @Description set the slot length and the booking durations of the building in minutes, booked orders are not changed
+// @ModuleID setBookingRules
+// @Accept  json
+// @Produce  json
+// @Param id path int true "building id"
+// @Param input body domain.BookingRules true "durations are whole slots, max_duration may be null"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /building/{id}/schedule/booking [put]
+func (h *Handler) setBookingRules(c *fiber.Ctx) error {
+	var input domain.BookingRules
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err = c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	if err = h.services.Schedule.SetBookingRules(id, input); err != nil {
+		return scheduleErrorResponse(c, err, nil)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Security User_Auth
+// @Security API_Key
+// @Tags pitch
+// @Description override the booking rules of the building for the pitch, null fields are taken from the building
+// @ModuleID setPitchBookingRules
+// @Accept  json
+// @Produce  json
+// @Param id path int true "pitch id"
+// @Param input body domain.PitchBookingRulesInput true "minutes, durations are whole slots"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /pitch/{id}/booking [put]
+func (h *Handler) setPitchBookingRules(c *fiber.Ctx) error {
+	var input domain.PitchBookingRulesInput
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	if err = c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	if err = h.services.Schedule.SetPitchBookingRules(id, input); err != nil {
+		return scheduleErrorResponse(c, err, nil)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
@@ -0,0 +1,258 @@
+package v1
+
+import (
+	"carWash/internal/domain"
+	"carWash/pkg/validation/validationStructs"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+)
+
+type twoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	Challenge         string `json:"challenge"`
+}
+
+func (h *Handler) initTwoFactorRoutes(auth fiber.Router) {
+	auth.Post("sign-in/2fa", h.twoFactorSignIn)
+
+	twoFactor := auth.Group("/2fa", h.jwtMiddleware(), isManagerOrAdmin)
+	{
+		twoFactor.Get("", h.getTwoFactorStatus)
+		twoFactor.Post("/enroll", h.enrollTwoFactor)
+		twoFactor.Post("/enable", h.enableTwoFactor)
+		twoFactor.Post("/disable", h.disableTwoFactor)
+		twoFactor.Post("/recovery-codes", h.regenerateRecoveryCodes)
+	}
+}
+
+// twoFactorErrorResponse maps the errors shared by every endpoint that checks a code.
+func twoFactorErrorResponse(c *fiber.Ctx, err error) error {
+	var retryErr *domain.RetryAfterError
+	if errors.As(err, &retryErr) {
+		return retryAfterResponse(c, retryErr)
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidTwoFactorCode),
+		errors.Is(err, domain.ErrTwoFactorNotEnabled),
+		errors.Is(err, domain.ErrTwoFactorAlreadyEnabled):
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	case errors.Is(err, domain.ErrInvalidTwoFactorChallenge):
+		return c.Status(fiber.StatusUnauthorized).JSON(response{Message: err.Error()})
+	case errors.Is(err, domain.ErrTwoFactorRequired):
+		return c.Status(fiber.StatusForbidden).JSON(response{Message: err.Error()})
+	case errors.Is(err, domain.ErrTooManyAttempts):
+		return c.Status(fiber.StatusTooManyRequests).JSON(response{Message: err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+}
+
+// @Tags auth
+// @Description second sign-in step for accounts with 2FA, takes a code from the app or a recovery code
+// @ModuleID twoFactorSignIn
+// @Accept  json
+// @Produce  json
+// @Param input body domain.TwoFactorSignInInput true "challenge from sign-in and the code"
+// @Success 200 {object} tokenResponse
+// @Failure 400,401 {object} response
+// @Failure 429 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/sign-in/2fa [post]
+func (h *Handler) twoFactorSignIn(c *fiber.Ctx) error {
+	var input domain.TwoFactorSignInInput
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	res, err := h.services.TwoFactor.TwoFactorSignIn(input)
+
+	entry := domain.AuditEntry{Action: domain.AuditTwoFactorSignIn}
+
+	if res != nil {
+		entry.ActorId = &res.UserId
+	}
+
+	h.audit(c, entry, err)
+
+	if err != nil {
+		return twoFactorErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(tokenResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+	})
+}
+
+// @Tags auth
+// @Security User_Auth
+// @Description get 2FA status of the account
+// @ModuleID getTwoFactorStatus
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} domain.TwoFactorStatus
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/2fa [get]
+func (h *Handler) getTwoFactorStatus(c *fiber.Ctx) error {
+	userType, id := getUser(c)
+
+	status, err := h.services.TwoFactor.GetTwoFactorStatus(id, userType)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response{Message: err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(status)
+}
+
+// @Tags auth
+// @Security User_Auth
+// @Description start 2FA enrollment, the uri is shown to the user as a QR code
+// @ModuleID enrollTwoFactor
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} domain.TwoFactorEnrollment
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/2fa/enroll [post]
+func (h *Handler) enrollTwoFactor(c *fiber.Ctx) error {
+	_, id := getUser(c)
+
+	enrollment, err := h.services.TwoFactor.EnrollTwoFactor(id)
+
+	if err != nil {
+		return twoFactorErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(enrollment)
+}
+
+// @Tags auth
+// @Security User_Auth
+// @Description finish 2FA enrollment with the first code from the app, recovery codes are shown only once
+// @ModuleID enableTwoFactor
+// @Accept  json
+// @Produce  json
+// @Param input body domain.TwoFactorCodeInput true "code from the app"
+// @Success 200 {object} domain.RecoveryCodes
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/2fa/enable [post]
+func (h *Handler) enableTwoFactor(c *fiber.Ctx) error {
+	var input domain.TwoFactorCodeInput
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	_, id := getUser(c)
+
+	codes, err := h.services.TwoFactor.EnableTwoFactor(id, input.Code)
+
+	h.audit(c, domain.AuditEntry{
+		Action:     domain.AuditTwoFactorEnable,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   &id,
+	}, err)
+
+	if err != nil {
+		return twoFactorErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(codes)
+}
+
+// @Tags auth
+// @Security User_Auth
+// @Description turn 2FA off, not allowed for admins
+// @ModuleID disableTwoFactor
+// @Accept  json
+// @Produce  json
+// @Param input body domain.TwoFactorCodeInput true "code from the app or a recovery code"
+// @Success 200 {object} okResponse
+// @Failure 400,404 {object} response
+// @Failure 403 {object} response
+// @Failure 429 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/2fa/disable [post]
+func (h *Handler) disableTwoFactor(c *fiber.Ctx) error {
+	var input domain.TwoFactorCodeInput
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	userType, id := getUser(c)
+
+	err := h.services.TwoFactor.DisableTwoFactor(id, userType, input.Code)
+
+	h.audit(c, domain.AuditEntry{Action: domain.AuditTwoFactorOff, TargetType: domain.AuditTargetUser, TargetId: &id}, err)
+
+	if err != nil {
+		return twoFactorErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(okResponse{Message: "OK"})
+}
+
+// @Tags auth
+// @Security User_Auth
+// @Description replace all recovery codes with new ones
+// @ModuleID regenerateRecoveryCodes
+// @Accept  json
+// @Produce  json
+// @Param input body domain.TwoFactorCodeInput true "code from the app or a recovery code"
+// @Success 200 {object} domain.RecoveryCodes
+// @Failure 400,404 {object} response
+// @Failure 429 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /auth/2fa/recovery-codes [post]
+func (h *Handler) regenerateRecoveryCodes(c *fiber.Ctx) error {
+	var input domain.TwoFactorCodeInput
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response{Message: err.Error()})
+	}
+
+	ok, errs := validationStructs.ValidateStruct(input)
+
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(errs)
+	}
+
+	_, id := getUser(c)
+
+	codes, err := h.services.TwoFactor.RegenerateRecoveryCodes(id, input.Code)
+
+	if err != nil {
+		return twoFactorErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(codes)
+}
@@ -0,0 +1,45 @@
+package domain
+
+const (
+	PermBuildingEdit     = "building.edit"
+	PermBuildingDelete   = "building.delete"
+	PermPitchManage      = "pitch.manage"
+	PermOrderView        = "order.view"
+	PermOrderBlock       = "order.block"
+	PermStaffManage      = "staff.manage"
+	PermServiceManage    = "service.manage"
+	PermNotificationSend = "notification.send"
+)
+
+// BuildingPermissions are granted per building, the building owner holds all of them.
+// Permissions missing here are global and belong to admins only.
+var BuildingPermissions = []string{
+	PermBuildingEdit,
+	PermBuildingDelete,
+	PermPitchManage,
+	PermOrderView,
+	PermOrderBlock,
+	PermStaffManage,
+}
+
+type Role struct {
+	Id          int      `json:"id" db:"id"`
+	Name        string   `json:"name" db:"role_name"`
+	Description string   `json:"description" db:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type Staff struct {
+	Id          int    `json:"id" db:"id"`
+	BuildingId  int    `json:"building_id" db:"building_id"`
+	UserId      int    `json:"user_id" db:"user_id"`
+	UserName    string `json:"user_name" db:"user_name"`
+	PhoneNumber string `json:"phone_number" db:"phone_number"`
+	RoleId      int    `json:"role_id" db:"role_id"`
+	RoleName    string `json:"role_name" db:"role_name"`
+}
+
+type StaffInput struct {
+	PhoneNumber string `json:"phone_number" validate:"required"`
+	RoleId      int    `json:"role_id" validate:"required"`
+}
@@ -0,0 +1,22 @@
+package domain
+
+// AccountExport is everything kept about the user, cards are exported masked.
+type AccountExport struct {
+	Profile    *User         `json:"profile"`
+	Orders     interface{}   `json:"orders"`
+	Cards      []*MaskedCard `json:"cards"`
+	Comments   interface{}   `json:"comments"`
+	Favourites interface{}   `json:"favourites"`
+	Feedbacks  interface{}   `json:"feedbacks"`
+	ExportedAt int64         `json:"exported_at"`
+}
+
+type MaskedCard struct {
+	Id       int    `json:"id"`
+	FullName string `json:"full_name"`
+	Number   string `json:"number"`
+}
+
+type DeleteAccountInput struct {
+	Password string `json:"password" validate:"required"`
+}
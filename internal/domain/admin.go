@@ -0,0 +1,27 @@
+package domain
+
+type AdminUser struct {
+	Id           int      `json:"id" db:"id"`
+	Name         string   `json:"name" db:"user_name"`
+	PhoneNumber  string   `json:"phone_number" db:"phone_number"`
+	UserType     string   `json:"user_type" db:"user_type"`
+	IsActivated  bool     `json:"is_activated" db:"is_activated"`
+	IsBanned     bool     `json:"is_banned" db:"is_banned"`
+	BanReason    string   `json:"ban_reason,omitempty" db:"ban_reason"`
+	BannedAt     *float64 `json:"banned_at,omitempty" db:"banned_at"`
+	RegisteredAt float64  `json:"registered_at" db:"registered_at"`
+}
+
+type FilterForUser struct {
+	Search   string `json:"search" form:"search" query:"search"`
+	UserType string `json:"user_type" form:"user_type" query:"user_type" validate:"omitempty,oneof=user manager admin" enums:"user,manager,admin"`
+	IsBanned *bool  `json:"is_banned" form:"is_banned" query:"is_banned"`
+}
+
+type SetRoleInput struct {
+	UserType string `json:"user_type" validate:"required,oneof=user manager admin"`
+}
+
+type BanUserInput struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
@@ -0,0 +1,47 @@
+package domain
+
+type APIKey struct {
+	Id          int      `json:"id" db:"id"`
+	UserId      int      `json:"-" db:"user_id"`
+	UserType    string   `json:"-" db:"user_type"`
+	Name        string   `json:"name" db:"key_name"`
+	Prefix      string   `json:"prefix" db:"prefix"`
+	Buildings   []int    `json:"buildings"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   float64  `json:"created_at" db:"created_at"`
+	LastUsedAt  *float64 `json:"last_used_at" db:"last_used_at"`
+	LastUsedIp  string   `json:"last_used_ip" db:"last_used_ip"`
+	RevokedAt   *float64 `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// Allows reports whether the key is scoped to the permission on the building,
+// the owner still has to hold the permission as well.
+func (k *APIKey) Allows(buildingId int, permission string) bool {
+	building, granted := false, false
+
+	for _, id := range k.Buildings {
+		if id == buildingId {
+			building = true
+		}
+	}
+
+	for _, p := range k.Permissions {
+		if p == permission {
+			granted = true
+		}
+	}
+
+	return building && granted
+}
+
+type APIKeyInput struct {
+	Name        string   `json:"name" validate:"required,max=100"`
+	Buildings   []int    `json:"buildings" validate:"required,min=1"`
+	Permissions []string `json:"permissions" validate:"required,min=1"`
+}
+
+// CreatedAPIKey is returned once, only the hash of Key is stored.
+type CreatedAPIKey struct {
+	Id  int    `json:"id"`
+	Key string `json:"key"`
+}
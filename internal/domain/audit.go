@@ -0,0 +1,59 @@
+package domain
+
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+
+	AuditSignIn          = "auth.sign_in"
+	AuditTwoFactorSignIn = "auth.sign_in_2fa"
+	AuditPasswordChange  = "auth.password_change"
+	AuditPasswordReset   = "auth.password_reset"
+	AuditPhoneChange     = "auth.phone_change"
+	AuditSessionRevoke   = "auth.session_revoke"
+	AuditSessionsRevoke  = "auth.sessions_revoke"
+	AuditTwoFactorEnable = "auth.2fa_enable"
+	AuditTwoFactorOff    = "auth.2fa_disable"
+	AuditGuestClaim      = "auth.guest_claim"
+	AuditAPIKeyCreate    = "auth.api_key_create"
+	AuditAPIKeyRevoke    = "auth.api_key_revoke"
+	AuditAccountExport   = "auth.account_export"
+	AuditAccountDelete   = "auth.account_delete"
+
+	AuditUserRole       = "admin.user_role"
+	AuditUserBan        = "admin.user_ban"
+	AuditUserUnban      = "admin.user_unban"
+	AuditManagerApprove = "admin.manager_approve"
+	AuditManagerReject  = "admin.manager_reject"
+	AuditTwoFactorReset = "admin.2fa_reset"
+	AuditStaffAdd       = "building.staff_add"
+	AuditStaffRemove    = "building.staff_remove"
+	AuditOrderBlock     = "building.order_block"
+
+	AuditTargetUser     = "user"
+	AuditTargetSession  = "session"
+	AuditTargetBuilding = "building"
+	AuditTargetAPIKey   = "api_key"
+	AuditTargetPitch    = "pitch"
+)
+
+type AuditEntry struct {
+	Id         int64   `json:"id" db:"id"`
+	ActorId    *int    `json:"actor_id,omitempty" db:"actor_id"`
+	ActorType  string  `json:"actor_type,omitempty" db:"actor_type"`
+	Action     string  `json:"action" db:"action"`
+	TargetType string  `json:"target_type,omitempty" db:"target_type"`
+	TargetId   *int    `json:"target_id,omitempty" db:"target_id"`
+	Ip         string  `json:"ip" db:"ip"`
+	UserAgent  string  `json:"user_agent" db:"user_agent"`
+	Outcome    string  `json:"outcome" db:"outcome"`
+	Details    string  `json:"details,omitempty" db:"details"`
+	CreatedAt  float64 `json:"created_at" db:"created_at"`
+}
+
+// FilterForAudit matches UserId against both the actor and a user target, From and To are epoch seconds.
+type FilterForAudit struct {
+	UserId int     `json:"user_id" form:"user_id" query:"user_id"`
+	Action string  `json:"action" form:"action" query:"action"`
+	From   float64 `json:"from" form:"from" query:"from"`
+	To     float64 `json:"to" form:"to" query:"to"`
+}
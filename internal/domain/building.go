@@ -1,25 +1,45 @@
 package domain
 
+import "time"
+
+// DefaultTimezone is used for buildings created without one.
+const DefaultTimezone = "Asia/Almaty"
+
+// Location returns the timezone of a building, a name that cannot be loaded falls back to UTC.
+func Location(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 type Building struct {
-	Id              int     `json:"id,omitempty" db:"id"`
-	Name            string  `json:"name" db:"building_name"`
-	Address         string  `json:"address" db:"address"`
-	PhoneNumber     string  `json:"phone_number" db:"phone_number"`
-	Instagram       string  `json:"instagram" db:"instagram"`
-	Description     string  `json:"description" db:"description"`
-	BuildingImage   string  `json:"building_image" db:"building_image"`
-	ManagerId       int     `json:"manager_id,omitempty" db:"manager_id"`
-	WorkTime        int     `json:"work_time,omitempty" db:"work_time"`
-	StartTime       int     `json:"start-time,omitempty"`
-	StartTimeString string  `json:"start_time,omitempty" db:"start_time"`
-	EndTimeString   string  `json:"end_time,omitempty" db:"end_time"`
-	EndTime         int     `json:"end-time,omitempty"`
-	MinPrice        int     `json:"price" db:"min_price"`
-	Longtitude      string  `json:"longitude,omitempty"  db:"longtitude"`
-	Latitude        string  `json:"latitude,omitempty"    db:"latitude"`
-	IsFavourite     bool    `json:"is_favourite" db:"is_favourite"`
-	Grade           float64 `json:"grade" db:"grade"`
-	CountGradedUser int     `json:"count_graded_user"`
+	Id              int      `json:"id,omitempty" db:"id"`
+	Name            string   `json:"name" db:"building_name"`
+	Address         string   `json:"address" db:"address"`
+	PhoneNumber     string   `json:"phone_number" db:"phone_number"`
+	Instagram       string   `json:"instagram" db:"instagram"`
+	Description     string   `json:"description" db:"description"`
+	BuildingImage   string   `json:"building_image" db:"building_image"`
+	ManagerId       int      `json:"manager_id,omitempty" db:"manager_id"`
+	WorkTime        int      `json:"work_time,omitempty" db:"work_time"`
+	StartTime       int      `json:"start-time,omitempty"`
+	StartTimeString string   `json:"start_time,omitempty" db:"start_time"`
+	EndTimeString   string   `json:"end_time,omitempty" db:"end_time"`
+	EndTime         int      `json:"end-time,omitempty"`
+	MinPrice        int      `json:"price" db:"min_price"`
+	Longtitude      *float64 `json:"longitude,omitempty"  db:"longtitude"`
+	Latitude        *float64 `json:"latitude,omitempty"    db:"latitude"`
+	Distance        *float64 `json:"distance,omitempty" db:"distance"`
+	IsFavourite     bool     `json:"is_favourite" db:"is_favourite"`
+	SlotLength      int      `json:"slot_length,omitempty" db:"slot_length"`
+	MinDuration     int      `json:"min_duration,omitempty" db:"min_duration"`
+	MaxDuration     *int     `json:"max_duration,omitempty" db:"max_duration"`
+	Timezone        string   `json:"timezone,omitempty" db:"timezone"`
+	Grade           float64  `json:"grade" db:"grade"`
+	CountGradedUser int      `json:"count_graded_user"`
+	Images          []*Image `json:"images,omitempty" db:"-"`
 	Favourite       `json:"favourite,omitempty" db:"f"`
 }
 
@@ -28,9 +48,28 @@ type UserInfo struct {
 	Type string
 }
 
+// FilterForBuilding searches around Lat and Lng when both are set, Radius is in meters
+// and the distance to every building is returned in meters too. Results of a text search
+// by Q are ranked unless they are sorted by distance.
 type FilterForBuilding struct {
-	PitchType  int  `json:"pitch_type" form:"pitch_type" query:"pitch_type"  enums:"1,2,3"`
-	PitchExtra int  `json:"pitch_extra" form:"pitch_extra" query:"pitch_extra" enums:"1,2"`
-	StartCost  *int `json:"start_cost" form:"start_cost" query:"start_cost"`
-	EndCost    *int `json:"end_cost" form:"end_cost" query:"end_cost"`
+	Q          string   `json:"q" form:"q" query:"q" validate:"max=200"`
+	PitchType  int      `json:"pitch_type" form:"pitch_type" query:"pitch_type"  enums:"1,2,3"`
+	PitchExtra int      `json:"pitch_extra" form:"pitch_extra" query:"pitch_extra" enums:"1,2"`
+	StartCost  *int     `json:"start_cost" form:"start_cost" query:"start_cost"`
+	EndCost    *int     `json:"end_cost" form:"end_cost" query:"end_cost"`
+	Lat        *float64 `json:"lat" form:"lat" query:"lat" validate:"required_with=Lng Radius Sort,omitempty,min=-90,max=90"`
+	Lng        *float64 `json:"lng" form:"lng" query:"lng" validate:"required_with=Lat Radius Sort,omitempty,min=-180,max=180"`
+	Radius     float64  `json:"radius" form:"radius" query:"radius" validate:"omitempty,gt=0,max=20000000"`
+	Sort       string   `json:"sort" form:"sort" query:"sort" enums:"distance" validate:"omitempty,oneof=distance"`
+}
+
+type BuildingSuggestion struct {
+	Id      int    `json:"id" db:"id"`
+	Name    string `json:"name" db:"building_name"`
+	Address string `json:"address" db:"address"`
+}
+
+type SuggestFilter struct {
+	Q     string `json:"q" query:"q" validate:"required,max=200"`
+	Limit int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=20"`
 }
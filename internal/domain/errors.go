@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"time"
 )
 
 var (
@@ -14,4 +15,52 @@ var (
 	ErrNotFound                  = errors.New("не найдено")
 	ErrUserCommented             = errors.New("пользователь уже коммент оставил")
 	ErrBuildingExistINFavourites = errors.New("это здания уже существует")
+	ErrInvalidRefreshToken       = errors.New("неверный refresh токен")
+	ErrRefreshTokenExpired       = errors.New("срок действия refresh токена истек")
+	ErrRefreshTokenReused        = errors.New("refresh токен уже был использован, сессия завершена")
+	ErrSessionRevoked            = errors.New("сессия завершена")
+	ErrTooManyAttempts           = errors.New("слишком много неверных попыток, попробуйте позже")
+	ErrSecretCodeCooldown        = errors.New("код уже отправлен, повторная отправка будет доступна позже")
+	ErrRateLimited               = errors.New("слишком много запросов, попробуйте позже")
+	ErrUserBanned                = errors.New("учетная запись заблокирована")
+	ErrSelfModification          = errors.New("нельзя изменить собственную учетную запись")
+	ErrManagerNotApproved        = errors.New("учетная запись менеджера еще не одобрена")
+	ErrManagerAlreadyReviewed    = errors.New("заявка менеджера уже рассмотрена")
+	ErrPermissionDenied          = errors.New("недостаточно прав")
+	ErrStaffIsOwner              = errors.New("владелец площадки не может быть добавлен в персонал")
+	ErrInvalidEmailToken         = errors.New("неверная или устаревшая ссылка подтверждения почты")
+	ErrTwoFactorAlreadyEnabled   = errors.New("двухфакторная аутентификация уже включена")
+	ErrTwoFactorNotEnabled       = errors.New("двухфакторная аутентификация не включена")
+	ErrTwoFactorRequired         = errors.New("для этой учетной записи требуется двухфакторная аутентификация")
+	ErrInvalidTwoFactorCode      = errors.New("неверный код двухфакторной аутентификации")
+	ErrInvalidTwoFactorChallenge = errors.New("сессия входа истекла, войдите заново")
+	ErrInvalidAPIKey             = errors.New("неверный или отозванный API-ключ")
+	ErrInvalidPermission         = errors.New("право доступа не может быть выдано API-ключу")
+	ErrWrongPassword             = errors.New("неверный пароль")
+	ErrAccountHasBuildings       = errors.New("перед удалением учетной записи удалите свои площадки")
+	ErrInvalidCoordinates        = errors.New("укажите координаты площадки")
+	ErrInvalidViewport           = errors.New("южная граница карты должна быть не выше северной")
+	ErrCoverImage                = errors.New("нельзя удалить обложку, сначала выберите другую")
+	ErrTooManyImages             = errors.New("превышено количество изображений в галерее")
+	ErrImageOrder                = errors.New("порядок должен содержать все изображения галереи")
+	ErrInvalidSchedule           = errors.New("неверное время работы площадки")
+	ErrScheduleConflict          = errors.New("есть бронирования вне нового времени работы")
+	ErrInvalidBookingRules       = errors.New("продолжительность бронирования должна быть кратна длине слота")
+	ErrInvalidBookingTime        = errors.New("время бронирования не совпадает со слотами площадки")
+	ErrBookingDuration           = errors.New("продолжительность бронирования вне допустимых пределов")
+	ErrTimeBooked                = errors.New("выбранное время уже занято")
 )
+
+// RetryAfterError is returned when a request is throttled and may be repeated after RetryAfter.
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
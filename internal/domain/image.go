@@ -0,0 +1,24 @@
+package domain
+
+const (
+	ImageOwnerBuilding = "building"
+	ImageOwnerPitch    = "pitch"
+)
+
+// ImageOwner is the building or the pitch a gallery belongs to.
+type ImageOwner struct {
+	Type string
+	Id   int
+}
+
+type Image struct {
+	Id       int    `json:"id" db:"id"`
+	Image    string `json:"image" db:"image"`
+	Position int    `json:"position" db:"position"`
+	IsCover  bool   `json:"is_cover" db:"is_cover"`
+}
+
+// ImageOrderInput lists every image of the gallery in the new order.
+type ImageOrderInput struct {
+	Ids []int `json:"ids" validate:"required,min=1,dive,gt=0"`
+}
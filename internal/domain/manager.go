@@ -0,0 +1,36 @@
+package domain
+
+const (
+	ManagerPending  = "pending"
+	ManagerApproved = "approved"
+	ManagerRejected = "rejected"
+)
+
+type ManagerBusiness struct {
+	LegalName    string `json:"legal_name" db:"legal_name" validate:"required,max=255"`
+	TaxId        string `json:"tax_id" db:"tax_id" validate:"required,len=12,numeric"`
+	ContactName  string `json:"contact_name" db:"contact_name" validate:"required,max=255"`
+	ContactPhone string `json:"contact_phone" db:"contact_phone" validate:"required,e164"`
+	ContactEmail string `json:"contact_email" db:"contact_email" validate:"omitempty,email"`
+}
+
+type ManagerProfile struct {
+	UserId       int      `json:"user_id" db:"user_id"`
+	Name         string   `json:"name" db:"user_name"`
+	PhoneNumber  string   `json:"phone_number" db:"phone_number"`
+	Language     string   `json:"-" db:"language"`
+	Status       string   `json:"status" db:"status"`
+	RejectReason string   `json:"reject_reason,omitempty" db:"reject_reason"`
+	ReviewedBy   *int     `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt   *float64 `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt    float64  `json:"created_at" db:"created_at"`
+	ManagerBusiness
+}
+
+type FilterForManager struct {
+	Status string `json:"status" form:"status" query:"status" enums:"pending,approved,rejected"`
+}
+
+type RejectManagerInput struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+// MapFilter is the visible part of the map, West is greater than East when the
+// viewport crosses the antimeridian.
+type MapFilter struct {
+	North *float64 `json:"north" query:"north" validate:"required,min=-90,max=90"`
+	South *float64 `json:"south" query:"south" validate:"required,min=-90,max=90"`
+	East  *float64 `json:"east" query:"east" validate:"required,min=-180,max=180"`
+	West  *float64 `json:"west" query:"west" validate:"required,min=-180,max=180"`
+	Zoom  *int     `json:"zoom" query:"zoom" validate:"required,min=0,max=22"`
+}
+
+type MapBuilding struct {
+	Id            int     `json:"id" db:"id"`
+	Name          string  `json:"name" db:"building_name"`
+	Address       string  `json:"address" db:"address"`
+	BuildingImage string  `json:"building_image" db:"building_image"`
+	Latitude      float64 `json:"latitude" db:"latitude"`
+	Longtitude    float64 `json:"longitude" db:"longtitude"`
+	MinPrice      int     `json:"price" db:"min_price"`
+}
+
+// MapCluster groups the buildings of one grid cell, BuildingId is set when the cell
+// holds a single building so it can be drawn as a usual marker.
+type MapCluster struct {
+	Count      int     `json:"count" db:"count"`
+	Latitude   float64 `json:"latitude" db:"latitude"`
+	Longtitude float64 `json:"longitude" db:"longtitude"`
+	MinPrice   int     `json:"price" db:"min_price"`
+	BuildingId *int    `json:"building_id,omitempty" db:"building_id"`
+}
+
+// MapResponse has either buildings or clusters depending on the zoom.
+type MapResponse struct {
+	Clustered bool           `json:"clustered"`
+	Buildings []*MapBuilding `json:"buildings"`
+	Clusters  []*MapCluster  `json:"clusters"`
+}
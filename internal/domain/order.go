@@ -1,5 +1,10 @@
 package domain
 
+import "time"
+
+// OrderCancelled is set when the venue cancels the order, its times are free again.
+const OrderCancelled = 3
+
 type Order struct {
 	Id           int            `json:"id"  db:"id"`
 	PitchId      int            `json:"pitch_id,omitempty" db:"pitch_id"`
@@ -13,12 +18,18 @@ type Order struct {
 	BuildingName string         `json:"building_name" db:"building_name"`
 	Address      string         `json:"address" db:"address"`
 	OrderDate    float64        `json:"order_date" db:"order_date"`
+	Date         string         `json:"date"`
+	StartDate    float64        `json:"start_order_date" db:"start_order_date"`
+	EndDate      float64        `json:"end_order_date" db:"end_order_date"`
+	StartAt      string         `json:"start_at"`
+	EndAt        string         `json:"end_at"`
+	Timezone     string         `json:"timezone" db:"timezone"`
+	StartTime    string         `json:"start_time,omitempty"`
+	EndTime      string         `json:"end_time,omitempty"`
 	Status       int            `json:"status" db:"status"`
-	Times        []string       `json:"times,omitempty" `
 	ServiceIds   []int          `json:"service_ids,omitempty"`
 	CardId       int            `json:"card_id" db:"card_id"`
 	ExtraInfo    string         `json:"extra_info"`
-	TimeInput    []TimeInput    `json:"collection_times,omitempty"`
 	ServiceInput []ServiceInput `json:"collection_service,omitempty"`
 }
 
@@ -28,25 +39,46 @@ type ServiceInput struct {
 	ServiceCost int    `json:"price" db:"price"`
 }
 
-type TimeInput struct {
-	Id         int    `json:"id,omitempty"  db:"id"`
-	WorkTime   string `json:"order_work_time" db:"order_work_time"`
-	BuildingId int    `json:"building_id,omitempty" db:"building_id"`
+// SetLocalTimes fills the business date and the RFC 3339 times in the timezone of the building.
+func (o *Order) SetLocalTimes() {
+	loc := Location(o.Timezone)
+
+	o.Date = time.Unix(int64(o.OrderDate), 0).UTC().Format("2006-01-02")
+	o.StartAt = time.Unix(int64(o.StartDate), 0).In(loc).Format(time.RFC3339)
+	o.EndAt = time.Unix(int64(o.EndDate), 0).In(loc).Format(time.RFC3339)
+}
+
+// BookedInterval is the time taken by an order, both ends are unix seconds.
+type BookedInterval struct {
+	Start float64 `db:"start_order_date"`
+	End   float64 `db:"end_order_date"`
 }
 
+// OrderTime is a slot of the business day in the local time of the building, Date is the
+// calendar date it starts on and is the next one for the slots after midnight.
 type OrderTime struct {
-	Id       int    `json:"id,omitempty" db:"id"`
-	WorkTime string `json:"work_time" db:"work_time"`
-	IsBooked bool   `json:"is_booked" db:"is_booked"`
+	Id        int     `json:"id,omitempty" db:"id"`
+	Date      string  `json:"date"`
+	WorkTime  string  `json:"work_time" db:"work_time"`
+	StartDate float64 `json:"start_date"`
+	EndDate   float64 `json:"end_date"`
+	StartAt   string  `json:"start_at"`
+	EndAt     string  `json:"end_at"`
+	IsBooked  bool    `json:"is_booked" db:"is_booked"`
 }
 
+// FilterForOrderTimes takes the business date as Date or as OrderDate in unix seconds,
+// without both it is today in the local time of the building.
 type FilterForOrderTimes struct {
+	Date       string  `json:"date" form:"date" query:"date" validate:"omitempty,datetime=2006-01-02"`
 	OrderDate  float64 `json:"order_date" form:"order_date" query:"order_date"`
-	BuildingId int     `json:"building_id" form:"building_id" query:"building_id"`
+	BuildingId int     `json:"building_id" form:"building_id" query:"building_id" validate:"required"`
 	PitchId    int     `json:"pitch_id" form:"pitch_id"   query:"pitch_id"`
 }
 
 type FilterForOrder struct {
 	OrderStatus int     `json:"order_status" form:"order_status" query:"order_status" enums:"1,2"`
+	Date        string  `json:"date" form:"date" query:"date" validate:"omitempty,datetime=2006-01-02"`
 	OrderDate   float64 `json:"order_date" form:"order_date" query:"order_date"`
+	BuildingId  int     `json:"building_id" form:"building_id" query:"building_id"`
 }
@@ -1,11 +1,15 @@
 package domain
 
+// Pitch keeps only the booking rules that differ from its building, nil ones are taken from it.
 type Pitch struct {
-	Id         int    `json:"id" db:"id"`
-	BuildingId int    `json:"building_id" db:"building_id"`
-	Price      int    `json:"price" db:"price"`
-	Image      string `json:"image" db:"pitch_image"`
-	PitchType  int    `json:"pitch_type" db:"pitch_type"`
-	PitchExtra int    `json:"pitch_extra" db:"pitch_extra"`
-	ManagerId  int    `json:"manager_id,omitempty" `
+	Id          int      `json:"id" db:"id"`
+	BuildingId  int      `json:"building_id" db:"building_id"`
+	Price       int      `json:"price" db:"price"`
+	Image       string   `json:"image" db:"pitch_image"`
+	PitchType   int      `json:"pitch_type" db:"pitch_type"`
+	PitchExtra  int      `json:"pitch_extra" db:"pitch_extra"`
+	SlotLength  *int     `json:"slot_length,omitempty" db:"slot_length"`
+	MinDuration *int     `json:"min_duration,omitempty" db:"min_duration"`
+	MaxDuration *int     `json:"max_duration,omitempty" db:"max_duration"`
+	Images      []*Image `json:"images,omitempty" db:"-"`
 }
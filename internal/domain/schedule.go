@@ -0,0 +1,118 @@
+package domain
+
+import "fmt"
+
+// BuildingHours are the opening hours of one weekday, 1 is Monday and 7 is Sunday.
+// Times are "15:04", the close time may be "24:00". A close time not after the open time
+// is on the next day, the hours after midnight still belong to the weekday.
+type BuildingHours struct {
+	Weekday   int    `json:"weekday" db:"weekday" validate:"min=1,max=7"`
+	IsClosed  bool   `json:"is_closed" db:"is_closed"`
+	OpenTime  string `json:"open_time" db:"open_time"`
+	CloseTime string `json:"close_time" db:"close_time"`
+}
+
+// ScheduleException replaces the weekly hours on one date, the building is either
+// closed for the day or works the given hours.
+type ScheduleException struct {
+	Id        int     `json:"id" db:"id"`
+	Date      string  `json:"date" db:"exception_date" validate:"required,datetime=2006-01-02"`
+	IsClosed  bool    `json:"is_closed" db:"is_closed"`
+	OpenTime  *string `json:"open_time,omitempty" db:"open_time"`
+	CloseTime *string `json:"close_time,omitempty" db:"close_time"`
+	Reason    string  `json:"reason" db:"reason" validate:"max=255"`
+}
+
+type BuildingSchedule struct {
+	Booking    *BookingRules        `json:"booking"`
+	Week       []*BuildingHours     `json:"week"`
+	Exceptions []*ScheduleException `json:"exceptions"`
+}
+
+// Resolutions of a schedule change that leaves booked orders outside the new hours,
+// without one the change is rejected with the list of conflicts.
+const (
+	ScheduleKeep   = "keep"
+	ScheduleCancel = "cancel"
+)
+
+type WeekScheduleInput struct {
+	Days       []BuildingHours `json:"days" validate:"required,min=1,max=7,dive"`
+	Resolution string          `json:"resolution" validate:"omitempty,oneof=keep cancel" enums:"keep,cancel"`
+}
+
+type ScheduleExceptionInput struct {
+	ScheduleException
+	Resolution string `json:"resolution" validate:"omitempty,oneof=keep cancel" enums:"keep,cancel"`
+}
+
+// ScheduleConflict is an upcoming order that does not fit into the new hours.
+type ScheduleConflict struct {
+	OrderId      int     `json:"order_id" db:"order_id"`
+	PitchId      int     `json:"pitch_id" db:"pitch_id"`
+	OrderDate    float64 `json:"order_date" db:"order_date"`
+	StartDate    float64 `json:"start_order_date" db:"start_order_date"`
+	EndDate      float64 `json:"end_order_date" db:"end_order_date"`
+	StartAt      string  `json:"start_at" db:"-"`
+	EndAt        string  `json:"end_at" db:"-"`
+	PhoneNumber  string  `json:"-" db:"phone_number"`
+	BuildingName string  `json:"-" db:"building_name"`
+}
+
+// DefaultSlotLength is the slot length of a new building in minutes.
+const DefaultSlotLength = 30
+
+// BookingRules are in minutes, an order takes whole slots and lasts from MinDuration
+// to MaxDuration, a nil MaxDuration does not limit it.
+type BookingRules struct {
+	SlotLength  int  `json:"slot_length" db:"slot_length" validate:"required,oneof=30 60 90" enums:"30,60,90"`
+	MinDuration int  `json:"min_duration" db:"min_duration" validate:"required,min=30,max=1440"`
+	MaxDuration *int `json:"max_duration" db:"max_duration" validate:"omitempty,min=30,max=1440"`
+}
+
+// PitchBookingRulesInput overrides the rules of the building for one pitch, nil fields
+// are taken from the building.
+type PitchBookingRulesInput struct {
+	SlotLength  *int `json:"slot_length" db:"slot_length" validate:"omitempty,oneof=30 60 90" enums:"30,60,90"`
+	MinDuration *int `json:"min_duration" db:"min_duration" validate:"omitempty,min=30,max=1440"`
+	MaxDuration *int `json:"max_duration" db:"max_duration" validate:"omitempty,min=30,max=1440"`
+}
+
+// PitchBookingRules are the rules that apply to the pitch after the building ones are filled in.
+type PitchBookingRules struct {
+	BuildingId int `db:"building_id"`
+	BookingRules
+}
+
+// DayHours are the hours a building works on a date after the exceptions are applied,
+// Open and Close are minutes since midnight of the date, Close is past 1440 when the day
+// runs into the next one.
+type DayHours struct {
+	IsClosed bool
+	Open     int
+	Close    int
+}
+
+// DefaultWeek opens the building every day from start until the slot starting at end is over,
+// start and end are seconds since midnight as the building is created with, slotLength is in minutes.
+// An end before the start is on the next day.
+func DefaultWeek(start, end, slotLength int) []BuildingHours {
+	closeTime := end + slotLength*60
+
+	if start < end && closeTime > 24*3600 {
+		closeTime = 24 * 3600
+	}
+
+	week := make([]BuildingHours, 0, 7)
+
+	for weekday := 1; weekday <= 7; weekday++ {
+		week = append(week, BuildingHours{
+			Weekday:   weekday,
+			IsClosed:  start == end,
+			OpenTime:  fmt.Sprintf("%02d:%02d", start/3600, start%3600/60),
+			CloseTime: fmt.Sprintf("%02d:%02d", closeTime/3600, closeTime%3600/60),
+		})
+	}
+
+	return week
+}
@@ -3,6 +3,21 @@ package domain
 import "time"
 
 type Session struct {
-	RefreshToken string    `json:"refresh_token" db:"refresh_token"`
+	Id           int       `json:"id" db:"id"`
+	UserId       int       `json:"-" db:"user_id"`
+	UserType     string    `json:"-" db:"user_type"`
+	RefreshToken string    `json:"-" db:"refresh_token"`
 	ExpiresAt    time.Time `json:"expires_at" db:"expires_at"`
+	DeviceName   string    `json:"device_name" db:"device_name"`
+	Ip           string    `json:"ip" db:"ip"`
+	UserAgent    string    `json:"user_agent" db:"user_agent"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	LastUsedAt   time.Time `json:"last_used_at" db:"last_used_at"`
+	IsCurrent    bool      `json:"is_current" db:"-"`
+}
+
+type Device struct {
+	Name      string
+	Ip        string
+	UserAgent string
 }
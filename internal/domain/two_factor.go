@@ -0,0 +1,36 @@
+package domain
+
+type TwoFactor struct {
+	UserId  int    `db:"user_id"`
+	Secret  string `db:"secret"`
+	Enabled bool   `db:"enabled"`
+}
+
+type RecoveryCode struct {
+	Id       int    `db:"id"`
+	CodeHash string `db:"code_hash"`
+}
+
+type TwoFactorStatus struct {
+	Enabled  bool `json:"enabled"`
+	Required bool `json:"required"`
+}
+
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+// TwoFactorCodeInput takes either a code from the authenticator app or one of the recovery codes.
+type TwoFactorCodeInput struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type TwoFactorSignInInput struct {
+	Challenge string `json:"challenge" validate:"required"`
+	Code      string `json:"code" validate:"required"`
+}
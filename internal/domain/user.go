@@ -1,12 +1,14 @@
 package domain
 
 type User struct {
-	Id          int    `json:"id" db:"id"`
-	Name        string `json:"name" db:"user_name"`
-	PhoneNumber string `json:"phone_number" db:"phone_number"`
-	UserType    string `json:"user_type,omitempty" db:"user_type"`
-	Password    string `json:"password,omitempty" db:"password" `
-	IsActivated string `json:"is_activated,omitempty" db:"is_activated"`
+	Id            int    `json:"id" db:"id"`
+	Name          string `json:"name" db:"user_name"`
+	PhoneNumber   string `json:"phone_number" db:"phone_number"`
+	UserType      string `json:"user_type,omitempty" db:"user_type"`
+	Password      string `json:"password,omitempty" db:"password" `
+	IsActivated   string `json:"is_activated,omitempty" db:"is_activated"`
+	Email         string `json:"email,omitempty" db:"email"`
+	EmailVerified bool   `json:"email_verified" db:"email_verified"`
 }
 
 type UserUpdate struct {
@@ -40,3 +42,29 @@ type ResetPhoneNumberInput struct {
 	PhoneNumber string `json:"phone_number"           validate:"required"`
 	SecretCode  string `json:"secret_code"     validate:"required"`
 }
+
+type AttachEmailInput struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+type VerifyEmailInput struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type GuestCodeInput struct {
+	PhoneNumber string `json:"phone_number" validate:"required,e164"`
+}
+
+type GuestVerifyInput struct {
+	PhoneNumber string `json:"phone_number" validate:"required,e164"`
+	SecretCode  string `json:"secret_code" validate:"required"`
+	Name        string `json:"name" validate:"max=255"`
+	DeviceName  string `json:"device_name"`
+}
+
+type ClaimGuestInput struct {
+	Name            string `json:"name" validate:"max=255"`
+	Password        string `json:"password" validate:"required,min=8,max=64"`
+	ConfirmPassword string `json:"confirm_password" validate:"required"`
+	DeviceName      string `json:"device_name"`
+}
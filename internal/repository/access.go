@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"carWash/internal/domain"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+type AccessRepos struct {
+	db *sqlx.DB
+}
+
+func NewAccessRepos(db *sqlx.DB) *AccessRepos {
+	return &AccessRepos{db: db}
+}
+
+func (a *AccessRepos) GetRoles() ([]*domain.Role, error) {
+	roles := make([]*domain.Role, 0)
+
+	query := fmt.Sprintf("SELECT id, role_name, description FROM %s ORDER BY id ASC", roleTable)
+
+	err := a.db.Select(&roles, query)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetRoles: %w", err)
+	}
+
+	for _, role := range roles {
+		queryPermissions := fmt.Sprintf("SELECT permission FROM %s WHERE role_id = $1 ORDER BY permission", rolePermissionTable)
+
+		err = a.db.Select(&role.Permissions, queryPermissions, role.Id)
+
+		if err != nil {
+			return nil, fmt.Errorf("repository.GetRoles: %w", err)
+		}
+	}
+
+	return roles, nil
+}
+
+func (a *AccessRepos) GetBuildingOwner(buildingId int) (int, error) {
+	var managerId int
+
+	query := fmt.Sprintf("SELECT manager_id FROM %s WHERE id = $1", buildingTable)
+
+	err := a.db.QueryRowx(query, buildingId).Scan(&managerId)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("repository.GetBuildingOwner: %w", domain.ErrNotFound)
+		}
+		return 0, fmt.Errorf("repository.GetBuildingOwner: %w", err)
+	}
+
+	return managerId, nil
+}
+
+// IsApprovedManager reports whether the user is a manager right now and their profile is approved.
+func (a *AccessRepos) IsApprovedManager(userId int) (bool, error) {
+	var approved bool
+
+	query := fmt.Sprintf(
+		`SELECT EXISTS (
+					SELECT 1
+					FROM
+						%s u
+					JOIN
+						%s m
+					ON
+						m.user_id = u.id
+					WHERE
+						u.id = $1 AND u.user_type = 'manager' AND m.status = $2
+				)`, userTable, managerTable)
+
+	if err := a.db.QueryRowx(query, userId, domain.ManagerApproved).Scan(&approved); err != nil {
+		return false, fmt.Errorf("repository.IsApprovedManager: %w", err)
+	}
+
+	return approved, nil
+}
+
+func (a *AccessRepos) GetPitchBuilding(pitchId int) (int, error) {
+	var buildingId int
+
+	query := fmt.Sprintf("SELECT building_id FROM %s WHERE id = $1", pitchTable)
+
+	err := a.db.QueryRowx(query, pitchId).Scan(&buildingId)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("repository.GetPitchBuilding: %w", domain.ErrNotFound)
+		}
+		return 0, fmt.Errorf("repository.GetPitchBuilding: %w", err)
+	}
+
+	return buildingId, nil
+}
+
+// GetStaffPermissions returns the permissions granted to the user on the building through staff roles.
+func (a *AccessRepos) GetStaffPermissions(userId, buildingId int) ([]string, error) {
+	permissions := make([]string, 0)
+
+	query := fmt.Sprintf(
+		`SELECT
+					rp.permission
+				FROM
+					%s s
+				INNER JOIN
+					%s rp
+				ON
+					s.role_id = rp.role_id
+				WHERE
+					s.user_id = $1 AND s.building_id = $2`, buildingStaffTable, rolePermissionTable)
+
+	err := a.db.Select(&permissions, query, userId, buildingId)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetStaffPermissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+func (a *AccessRepos) GetStaff(buildingId int) ([]*domain.Staff, error) {
+	staff := make([]*domain.Staff, 0)
+
+	query := fmt.Sprintf(
+		`SELECT
+					s.id,
+					s.building_id,
+					s.user_id,
+					u.user_name,
+					u.phone_number,
+					s.role_id,
+					r.role_name
+				FROM
+					%s s
+				INNER JOIN
+					%s u
+				ON
+					s.user_id = u.id
+				INNER JOIN
+					%s r
+				ON
+					s.role_id = r.id
+				WHERE
+					s.building_id = $1
+				ORDER BY
+					s.id ASC`, buildingStaffTable, userTable, roleTable)
+
+	err := a.db.Select(&staff, query, buildingId)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetStaff: %w", err)
+	}
+
+	return staff, nil
+}
+
+// SetStaff adds the user to the building staff or changes the role of an existing member.
+func (a *AccessRepos) SetStaff(buildingId, userId, roleId int) (int, error) {
+	var id int
+
+	query := fmt.Sprintf(
+		`INSERT INTO
+					%s
+				(building_id, user_id, role_id)
+					VALUES
+				($1,$2,$3)
+				ON CONFLICT (building_id, user_id) DO UPDATE SET role_id = excluded.role_id
+				RETURNING id`, buildingStaffTable)
+
+	err := a.db.QueryRowx(query, buildingId, userId, roleId).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("repository.SetStaff: %w", err)
+	}
+
+	return id, nil
+}
+
+func (a *AccessRepos) RemoveStaff(buildingId, userId int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE building_id = $1 AND user_id = $2", buildingStaffTable)
+
+	result, err := a.db.Exec(query, buildingId, userId)
+
+	if err != nil {
+		return fmt.Errorf("repository.RemoveStaff: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository.RemoveStaff: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("repository.RemoveStaff: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (a *AccessRepos) RoleExists(roleId int) (bool, error) {
+	var exists bool
+
+	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1)", roleTable)
+
+	err := a.db.QueryRowx(query, roleId).Scan(&exists)
+
+	if err != nil {
+		return false, fmt.Errorf("repository.RoleExists: %w", err)
+	}
+
+	return exists, nil
+}
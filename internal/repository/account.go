@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"carWash/internal/domain"
+	"fmt"
+)
+
+// DeleteUser removes the account, its orders and reviews stay for the venues with the
+// personal data cleared. Managers have to delete their buildings first.
+func (u *UserAuthRepos) DeleteUser(id int) error {
+	var buildings int
+
+	tx := u.db.MustBegin()
+
+	queryBuildings := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE manager_id = $1", buildingTable)
+
+	if err := tx.QueryRowx(queryBuildings, id).Scan(&buildings); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.DeleteUser: %w", txErr)
+		}
+		return fmt.Errorf("repository.DeleteUser: %w", err)
+	}
+
+	if buildings != 0 {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.DeleteUser: %w", txErr)
+		}
+		return fmt.Errorf("repository.DeleteUser: %w", domain.ErrAccountHasBuildings)
+	}
+
+	queryOrders := fmt.Sprintf("UPDATE %s SET first_name = '', phone_number = '', extra_info = '' WHERE user_id = $1", orderTable)
+
+	if _, err := tx.Exec(queryOrders, id); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.DeleteUser: %w", txErr)
+		}
+		return fmt.Errorf("repository.DeleteUser: %w", err)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1", userTable)
+
+	result, err := tx.Exec(query, id)
+
+	if err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.DeleteUser: %w", txErr)
+		}
+		return fmt.Errorf("repository.DeleteUser: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+
+	if err != nil || affected == 0 {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.DeleteUser: %w", txErr)
+		}
+		return fmt.Errorf("repository.DeleteUser: %w", domain.ErrNotFound)
+	}
+
+	return tx.Commit()
+}
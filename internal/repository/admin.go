@@ -0,0 +1,420 @@
+package repository
+
+import (
+	"carWash/internal/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+)
+
+type AdminRepos struct {
+	db *sqlx.DB
+}
+
+func NewAdminRepos(db *sqlx.DB) *AdminRepos {
+	return &AdminRepos{db: db}
+}
+
+func (a *AdminRepos) GetAllUsers(ctx *fiber.Ctx, page domain.Pagination, filter domain.FilterForUser) (*domain.GetAllResponses, error) {
+	var (
+		setValues      string
+		forCheckValues []string
+		args           []interface{}
+		count          int
+	)
+
+	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
+
+	defer cancel()
+
+	if filter.Search != "" {
+		args = append(args, "%"+likeEscaper.Replace(filter.Search)+"%")
+		forCheckValues = append(forCheckValues, fmt.Sprintf("(phone_number ILIKE $%d OR user_name ILIKE $%d)", len(args), len(args)))
+	}
+
+	if filter.UserType != "" {
+		args = append(args, filter.UserType)
+		forCheckValues = append(forCheckValues, fmt.Sprintf("user_type = $%d", len(args)))
+	}
+
+	if filter.IsBanned != nil {
+		args = append(args, *filter.IsBanned)
+		forCheckValues = append(forCheckValues, fmt.Sprintf("is_banned = $%d", len(args)))
+	}
+
+	if len(forCheckValues) != 0 {
+		setValues = "WHERE " + strings.Join(forCheckValues, " AND ")
+	}
+
+	queryCount := fmt.Sprintf("SELECT COUNT(*) FROM %s %s", userTable, setValues)
+
+	err := a.db.QueryRowx(queryCount, args...).Scan(&count)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetAllUsers: %w", err)
+	}
+
+	offset, pagesCount := calculatePagination(&page, count)
+
+	inp := make([]*domain.AdminUser, 0, page.Limit)
+
+	query := fmt.Sprintf(
+		`SELECT
+					id,
+					user_name,
+					phone_number,
+					user_type,
+					is_activated,
+					is_banned,
+					ban_reason,
+					extract(epoch from banned_at::timestamp at time zone 'GMT') "banned_at",
+					extract(epoch from registered_at::timestamp at time zone 'GMT') "registered_at"
+				FROM
+					%s
+				%s
+					ORDER BY
+				id ASC
+					LIMIT $%d OFFSET $%d`, userTable, setValues, len(args)+1, len(args)+2)
+
+	err = a.db.Select(&inp, query, append(args, page.Limit, offset)...)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetAllUsers: %w", err)
+	}
+
+	pages := domain.PaginationPage{
+		Page:  page.Page,
+		Pages: pagesCount,
+		Count: count,
+	}
+	ans := domain.GetAllResponses{
+		Data:     inp,
+		PageInfo: pages,
+	}
+	return &ans, nil
+}
+
+func (a *AdminRepos) GetUserById(ctx *fiber.Ctx, id int) (*domain.AdminUser, error) {
+	var inp domain.AdminUser
+
+	_, cancel := context.WithTimeout(ctx.Context(), 500*time.Millisecond)
+
+	defer cancel()
+
+	query := fmt.Sprintf(
+		`SELECT
+					id,
+					user_name,
+					phone_number,
+					user_type,
+					is_activated,
+					is_banned,
+					ban_reason,
+					extract(epoch from banned_at::timestamp at time zone 'GMT') "banned_at",
+					extract(epoch from registered_at::timestamp at time zone 'GMT') "registered_at"
+				FROM
+					%s
+				WHERE
+					id = $1`, userTable)
+
+	err := a.db.Get(&inp, query, id)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("repository.GetUserById: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("repository.GetUserById: %w", err)
+	}
+
+	return &inp, nil
+}
+
+// SetRole changes the user type, a user made manager by an admin gets an approved manager profile
+// so the manager routes are open right away.
+func (a *AdminRepos) SetRole(ctx *fiber.Ctx, adminId, id int, userType string) error {
+	_, cancel := context.WithTimeout(ctx.Context(), 500*time.Millisecond)
+
+	defer cancel()
+
+	tx := a.db.MustBegin()
+
+	query := fmt.Sprintf("UPDATE %s SET user_type = $1 WHERE id = $2", userTable)
+
+	result, err := tx.Exec(query, userType, id)
+
+	if err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.SetRole: %w", txErr)
+		}
+		return fmt.Errorf("repository.SetRole: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+
+	if err != nil || affected == 0 {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.SetRole: %w", txErr)
+		}
+		return fmt.Errorf("repository.SetRole: %w", domain.ErrNotFound)
+	}
+
+	if userType == "manager" {
+		queryProfile := fmt.Sprintf(
+			`INSERT INTO
+					%s
+				(user_id, status, reviewed_by, reviewed_at)
+					VALUES
+				($1, $2, $3, now())
+				ON CONFLICT (user_id) DO UPDATE SET
+					status = excluded.status,
+					reject_reason = '',
+					reviewed_by = excluded.reviewed_by,
+					reviewed_at = excluded.reviewed_at`, managerTable)
+
+		if _, err = tx.Exec(queryProfile, id, domain.ManagerApproved, adminId); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return fmt.Errorf("repository.SetRole: %w", txErr)
+			}
+			return fmt.Errorf("repository.SetRole: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (a *AdminRepos) SetBanned(ctx *fiber.Ctx, id int, banned bool, reason string) error {
+	_, cancel := context.WithTimeout(ctx.Context(), 500*time.Millisecond)
+
+	defer cancel()
+
+	query := fmt.Sprintf(
+		`UPDATE
+					%s
+				SET
+					is_banned = $1,
+					ban_reason = $2,
+					banned_at = CASE WHEN $1 THEN now() ELSE NULL END
+				WHERE
+					id = $3`, userTable)
+
+	result, err := a.db.Exec(query, banned, reason, id)
+
+	if err != nil {
+		return fmt.Errorf("repository.SetBanned: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository.SetBanned: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("repository.SetBanned: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (a *AdminRepos) GetUserComments(ctx *fiber.Ctx, page domain.Pagination, userId int) (*domain.GetAllResponses, error) {
+	_, cancel := context.WithTimeout(ctx.Context(), 500*time.Millisecond)
+
+	defer cancel()
+
+	count, err := countPage(a.db, commentTable, fmt.Sprintf("WHERE user_id = %d", userId))
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetUserComments: %w", err)
+	}
+
+	offset, pagesCount := calculatePagination(&page, count)
+
+	inp := make([]*domain.Comment, 0, page.Limit)
+
+	query := fmt.Sprintf(
+		`SELECT
+					id,
+					user_id,
+					building_id,
+					comment,
+					grade,
+					extract(epoch from post_data::timestamp at time zone 'GMT') "post_data"
+				FROM
+					%s
+				WHERE
+					user_id = $1
+					ORDER BY
+				id DESC
+					LIMIT $2 OFFSET $3`, commentTable)
+
+	err = a.db.Select(&inp, query, userId, page.Limit, offset)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetUserComments: %w", err)
+	}
+
+	pages := domain.PaginationPage{
+		Page:  page.Page,
+		Pages: pagesCount,
+		Count: count,
+	}
+	ans := domain.GetAllResponses{
+		Data:     inp,
+		PageInfo: pages,
+	}
+	return &ans, nil
+}
+
+func (a *AdminRepos) GetUserFeedbacks(ctx *fiber.Ctx, page domain.Pagination, userId int) (*domain.GetAllResponses, error) {
+	_, cancel := context.WithTimeout(ctx.Context(), 500*time.Millisecond)
+
+	defer cancel()
+
+	count, err := countPage(a.db, feedbackTable, fmt.Sprintf("WHERE user_id = %d", userId))
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetUserFeedbacks: %w", err)
+	}
+
+	offset, pagesCount := calculatePagination(&page, count)
+
+	inp := make([]*domain.Feedback, 0, page.Limit)
+
+	query := fmt.Sprintf(
+		`SELECT
+					f.id,
+					f.user_id,
+					f.text,
+					u.phone_number,
+					u.user_name
+				FROM
+					%s f
+				INNER JOIN
+					%s u
+				ON
+					f.user_id = u.id
+				WHERE
+					f.user_id = $1
+					ORDER BY
+				f.id DESC
+					LIMIT $2 OFFSET $3`, feedbackTable, userTable)
+
+	err = a.db.Select(&inp, query, userId, page.Limit, offset)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetUserFeedbacks: %w", err)
+	}
+
+	pages := domain.PaginationPage{
+		Page:  page.Page,
+		Pages: pagesCount,
+		Count: count,
+	}
+	ans := domain.GetAllResponses{
+		Data:     inp,
+		PageInfo: pages,
+	}
+	return &ans, nil
+}
+
+func (a *AdminRepos) GetAllManagers(ctx *fiber.Ctx, page domain.Pagination, filter domain.FilterForManager) (*domain.GetAllResponses, error) {
+	var (
+		setValues string
+		args      []interface{}
+		count     int
+	)
+
+	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
+
+	defer cancel()
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		setValues = "WHERE m.status = $1"
+	}
+
+	queryCount := fmt.Sprintf("SELECT COUNT(*) FROM %s m %s", managerTable, setValues)
+
+	err := a.db.QueryRowx(queryCount, args...).Scan(&count)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetAllManagers: %w", err)
+	}
+
+	offset, pagesCount := calculatePagination(&page, count)
+
+	inp := make([]*domain.ManagerProfile, 0, page.Limit)
+
+	query := fmt.Sprintf(
+		`SELECT %s
+				FROM
+					%s m
+				INNER JOIN
+					%s u
+				ON
+					m.user_id = u.id
+				%s
+					ORDER BY
+				m.created_at ASC
+					LIMIT $%d OFFSET $%d`, managerProfileColumns, managerTable, userTable, setValues, len(args)+1, len(args)+2)
+
+	err = a.db.Select(&inp, query, append(args, page.Limit, offset)...)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetAllManagers: %w", err)
+	}
+
+	pages := domain.PaginationPage{
+		Page:  page.Page,
+		Pages: pagesCount,
+		Count: count,
+	}
+	ans := domain.GetAllResponses{
+		Data:     inp,
+		PageInfo: pages,
+	}
+	return &ans, nil
+}
+
+func (a *AdminRepos) GetManagerProfile(ctx *fiber.Ctx, userId int) (*domain.ManagerProfile, error) {
+	_, cancel := context.WithTimeout(ctx.Context(), 500*time.Millisecond)
+
+	defer cancel()
+
+	return getManagerProfile(a.db, userId)
+}
+
+func (a *AdminRepos) SetManagerStatus(ctx *fiber.Ctx, userId, reviewerId int, status, reason string) error {
+	_, cancel := context.WithTimeout(ctx.Context(), 500*time.Millisecond)
+
+	defer cancel()
+
+	query := fmt.Sprintf(
+		`UPDATE
+					%s
+				SET
+					status = $1, reject_reason = $2, reviewed_by = $3, reviewed_at = now()
+				WHERE
+					user_id = $4`, managerTable)
+
+	result, err := a.db.Exec(query, status, reason, reviewerId, userId)
+
+	if err != nil {
+		return fmt.Errorf("repository.SetManagerStatus: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository.SetManagerStatus: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("repository.SetManagerStatus: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
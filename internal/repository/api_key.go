@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"carWash/internal/domain"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+type APIKeyRepos struct {
+	db *sqlx.DB
+}
+
+func NewAPIKeyRepos(db *sqlx.DB) *APIKeyRepos {
+	return &APIKeyRepos{db: db}
+}
+
+func (a *APIKeyRepos) Create(userId int, name, prefix, keyHash string, buildings []int, permissions []string) (int, error) {
+	var id int
+
+	tx := a.db.MustBegin()
+
+	query := fmt.Sprintf(
+		`INSERT INTO
+					%s
+				(user_id, key_name, prefix, key_hash)
+					VALUES
+				($1,$2,$3,$4)
+					RETURNING id`, apiKeyTable)
+
+	if err := tx.QueryRowx(query, userId, name, prefix, keyHash).Scan(&id); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return 0, fmt.Errorf("repository.Create: %w", txErr)
+		}
+		return 0, fmt.Errorf("repository.Create: %w", err)
+	}
+
+	queryBuilding := fmt.Sprintf("INSERT INTO %s(api_key_id, building_id) VALUES($1,$2) ON CONFLICT DO NOTHING", apiKeyBuildingTable)
+
+	for _, buildingId := range buildings {
+		if _, err := tx.Exec(queryBuilding, id, buildingId); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return 0, fmt.Errorf("repository.Create: %w", txErr)
+			}
+			return 0, fmt.Errorf("repository.Create: %w", err)
+		}
+	}
+
+	queryPermission := fmt.Sprintf("INSERT INTO %s(api_key_id, permission) VALUES($1,$2) ON CONFLICT DO NOTHING", apiKeyPermissionTable)
+
+	for _, permission := range permissions {
+		if _, err := tx.Exec(queryPermission, id, permission); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return 0, fmt.Errorf("repository.Create: %w", txErr)
+			}
+			return 0, fmt.Errorf("repository.Create: %w", err)
+		}
+	}
+
+	return id, tx.Commit()
+}
+
+func (a *APIKeyRepos) GetAll(userId int) ([]*domain.APIKey, error) {
+	inp := make([]*domain.APIKey, 0)
+
+	query := fmt.Sprintf(
+		`SELECT
+					id,
+					user_id,
+					key_name,
+					prefix,
+					extract(epoch from created_at::timestamp at time zone 'GMT') "created_at",
+					extract(epoch from last_used_at::timestamp at time zone 'GMT') "last_used_at",
+					last_used_ip,
+					extract(epoch from revoked_at::timestamp at time zone 'GMT') "revoked_at"
+				FROM
+					%s
+				WHERE
+					user_id = $1
+				ORDER BY
+					id DESC`, apiKeyTable)
+
+	if err := a.db.Select(&inp, query, userId); err != nil {
+		return nil, fmt.Errorf("repository.GetAll: %w", err)
+	}
+
+	for _, key := range inp {
+		if err := a.loadScope(key); err != nil {
+			return nil, fmt.Errorf("repository.GetAll: %w", err)
+		}
+	}
+
+	return inp, nil
+}
+
+// GetByHash returns an active key together with its owner's type, keys of banned owners are not returned.
+func (a *APIKeyRepos) GetByHash(keyHash string) (*domain.APIKey, error) {
+	var inp domain.APIKey
+
+	query := fmt.Sprintf(
+		`SELECT
+					k.id,
+					k.user_id,
+					u.user_type,
+					k.key_name,
+					k.prefix
+				FROM
+					%s k
+				JOIN
+					%s u ON u.id = k.user_id
+				WHERE
+					k.key_hash = $1 AND k.revoked_at IS NULL AND u.is_banned = false`, apiKeyTable, userTable)
+
+	if err := a.db.Get(&inp, query, keyHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("repository.GetByHash: %w", domain.ErrInvalidAPIKey)
+		}
+		return nil, fmt.Errorf("repository.GetByHash: %w", err)
+	}
+
+	if err := a.loadScope(&inp); err != nil {
+		return nil, fmt.Errorf("repository.GetByHash: %w", err)
+	}
+
+	return &inp, nil
+}
+
+// Touch records the use of the key, at most once a minute to keep writes off the hot path.
+func (a *APIKeyRepos) Touch(id int, ip string) error {
+	query := fmt.Sprintf(
+		`UPDATE
+					%s
+				SET
+					last_used_at = now(), last_used_ip = $2
+				WHERE
+					id = $1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')`, apiKeyTable)
+
+	if _, err := a.db.Exec(query, id, ip); err != nil {
+		return fmt.Errorf("repository.Touch: %w", err)
+	}
+
+	return nil
+}
+
+func (a *APIKeyRepos) Revoke(id, userId int) error {
+	query := fmt.Sprintf("UPDATE %s SET revoked_at = now() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL", apiKeyTable)
+
+	result, err := a.db.Exec(query, id, userId)
+
+	if err != nil {
+		return fmt.Errorf("repository.Revoke: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository.Revoke: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("repository.Revoke: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (a *APIKeyRepos) loadScope(key *domain.APIKey) error {
+	key.Buildings = make([]int, 0)
+	key.Permissions = make([]string, 0)
+
+	queryBuildings := fmt.Sprintf("SELECT building_id FROM %s WHERE api_key_id = $1 ORDER BY building_id", apiKeyBuildingTable)
+
+	if err := a.db.Select(&key.Buildings, queryBuildings, key.Id); err != nil {
+		return err
+	}
+
+	queryPermissions := fmt.Sprintf("SELECT permission FROM %s WHERE api_key_id = $1 ORDER BY permission", apiKeyPermissionTable)
+
+	return a.db.Select(&key.Permissions, queryPermissions, key.Id)
+}
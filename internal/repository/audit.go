@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"carWash/internal/domain"
+	"context"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+)
+
+type AuditRepos struct {
+	db *sqlx.DB
+}
+
+func NewAuditRepos(db *sqlx.DB) *AuditRepos {
+	return &AuditRepos{db: db}
+}
+
+func (a *AuditRepos) Create(entry domain.AuditEntry) error {
+	query := fmt.Sprintf(
+		`INSERT INTO
+					%s
+				(actor_id, actor_type, action, target_type, target_id, ip, user_agent, outcome, details)
+					VALUES
+				($1,$2,$3,$4,$5,$6,$7,$8,$9)`, auditTable)
+
+	_, err := a.db.Exec(query, entry.ActorId, entry.ActorType, entry.Action, entry.TargetType, entry.TargetId,
+		entry.Ip, entry.UserAgent, entry.Outcome, entry.Details)
+
+	if err != nil {
+		return fmt.Errorf("repository.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (a *AuditRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, filter domain.FilterForAudit) (*domain.GetAllResponses, error) {
+	var (
+		setValues      string
+		forCheckValues []string
+		args           []interface{}
+		count          int
+	)
+
+	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
+
+	defer cancel()
+
+	if filter.UserId != 0 {
+		args = append(args, filter.UserId, domain.AuditTargetUser)
+		forCheckValues = append(forCheckValues, fmt.Sprintf("(actor_id = $%d OR (target_type = $%d AND target_id = $%d))", len(args)-1, len(args), len(args)-1))
+	}
+
+	if filter.Action != "" {
+		args = append(args, filter.Action)
+		forCheckValues = append(forCheckValues, fmt.Sprintf("action = $%d", len(args)))
+	}
+
+	if filter.From != 0 {
+		args = append(args, filter.From)
+		forCheckValues = append(forCheckValues, fmt.Sprintf("created_at >= to_timestamp($%d)", len(args)))
+	}
+
+	if filter.To != 0 {
+		args = append(args, filter.To)
+		forCheckValues = append(forCheckValues, fmt.Sprintf("created_at < to_timestamp($%d)", len(args)))
+	}
+
+	if len(forCheckValues) != 0 {
+		setValues = "WHERE " + strings.Join(forCheckValues, " AND ")
+	}
+
+	queryCount := fmt.Sprintf("SELECT COUNT(*) FROM %s %s", auditTable, setValues)
+
+	err := a.db.QueryRowx(queryCount, args...).Scan(&count)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetAll: %w", err)
+	}
+
+	offset, pagesCount := calculatePagination(&page, count)
+
+	inp := make([]*domain.AuditEntry, 0, page.Limit)
+
+	query := fmt.Sprintf(
+		`SELECT
+					id,
+					actor_id,
+					actor_type,
+					action,
+					target_type,
+					target_id,
+					ip,
+					user_agent,
+					outcome,
+					details,
+					extract(epoch from created_at::timestamp at time zone 'GMT') "created_at"
+				FROM
+					%s
+				%s
+					ORDER BY
+				id DESC
+					LIMIT $%d OFFSET $%d`, auditTable, setValues, len(args)+1, len(args)+2)
+
+	err = a.db.Select(&inp, query, append(args, page.Limit, offset)...)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetAll: %w", err)
+	}
+
+	pages := domain.PaginationPage{
+		Page:  page.Page,
+		Pages: pagesCount,
+		Count: count,
+	}
+	ans := domain.GetAllResponses{
+		Data:     inp,
+		PageInfo: pages,
+	}
+	return &ans, nil
+}
+
+// DeleteOlderThan is the only way entries leave the table, it is run by the retention job.
+func (a *AuditRepos) DeleteOlderThan(before time.Time) (int64, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE created_at < $1", auditTable)
+
+	result, err := a.db.Exec(query, before)
+
+	if err != nil {
+		return 0, fmt.Errorf("repository.DeleteOlderThan: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("repository.DeleteOlderThan: %w", err)
+	}
+
+	return affected, nil
+}
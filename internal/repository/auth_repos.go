@@ -131,39 +131,50 @@ func (u *UserAuthRepos) CreateUser(user domain.User) (int, error) {
 }
 
 func (u *UserAuthRepos) Verify(phone string) error {
-
-	tx := u.db.MustBegin()
 	var id int
 
-	fmt.Println(phone)
-
 	query := fmt.Sprintf("UPDATE %s SET is_activated = $1 WHERE phone_number = $2 AND is_activated = $3 RETURNING id", userTable)
 
-	err := tx.QueryRowx(query, true, phone, false).Scan(&id)
+	err := u.db.QueryRowx(query, true, phone, false).Scan(&id)
 
 	if err != nil {
-		err = tx.Rollback()
-		if err != nil {
-			return fmt.Errorf("repository.Verify: %w", err)
-		}
 		return fmt.Errorf("repository.Verify: %w", domain.ErrUserAlreadyExist)
 	}
 
-	queryInsert := fmt.Sprintf("INSERT INTO %s(user_id) VALUES($1)", sessionTable)
-	_, err = tx.Exec(queryInsert, id)
+	return nil
+}
+
+// ClaimGuest turns the unactivated account a guest booked with into a full one.
+func (u *UserAuthRepos) ClaimGuest(id int, name, password string) error {
+	query := fmt.Sprintf(
+		`UPDATE
+					%s
+				SET
+					password = $1, user_name = coalesce(nullif($2, ''), user_name), is_activated = true
+				WHERE
+					id = $3 AND is_activated = false`, userTable)
+
+	result, err := u.db.Exec(query, password, name, id)
 
 	if err != nil {
-		if err = tx.Rollback(); err != nil {
-			return fmt.Errorf("repository.Verify: %w", err)
-		}
-		return fmt.Errorf("repository.Verify: %w", err)
+		return fmt.Errorf("repository.ClaimGuest: %w", err)
 	}
-	return tx.Commit()
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository.ClaimGuest: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("repository.ClaimGuest: %w", domain.ErrUserAlreadyExist)
+	}
+
+	return nil
 }
 
 func (u *UserAuthRepos) GetUser(id int) (*domain.User, error) {
 	var inp domain.User
-	query := fmt.Sprintf("SELECT id,user_name,user_type,phone_number FROM %s WHERE id = $1", userTable)
+	query := fmt.Sprintf("SELECT id,user_name,user_type,phone_number,email,email_verified FROM %s WHERE id = $1", userTable)
 
 	err := u.db.Get(&inp, query, id)
 
@@ -173,70 +184,250 @@ func (u *UserAuthRepos) GetUser(id int) (*domain.User, error) {
 	return &inp, nil
 }
 
-func (u *UserAuthRepos) SignIn(phone, password string) (*domain.User, error) {
+func (u *UserAuthRepos) SetEmail(userId int, email string) error {
+	query := fmt.Sprintf("UPDATE %s SET email = $1, email_verified = false WHERE id = $2", userTable)
 
-	var input domain.User
-	query := fmt.Sprintf("SELECT id,user_type FROM %s WHERE phone_number = $1 AND password = $2 AND is_activated = $3", userTable)
+	_, err := u.db.Exec(query, email, userId)
+
+	if err != nil {
+		return fmt.Errorf("repository.SetEmail: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserAuthRepos) VerifyEmail(userId int, email string) error {
+	query := fmt.Sprintf("UPDATE %s SET email_verified = true WHERE id = $1 AND email = $2", userTable)
+
+	result, err := u.db.Exec(query, userId, email)
 
-	err := u.db.Get(&input, query, phone, password, true)
+	if err != nil {
+		return fmt.Errorf("repository.VerifyEmail: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository.VerifyEmail: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("repository.VerifyEmail: %w", domain.ErrInvalidEmailToken)
+	}
+
+	return nil
+}
+
+func (u *UserAuthRepos) SignIn(phone string) (*domain.User, error) {
+
+	var input struct {
+		domain.User
+		IsBanned bool `db:"is_banned"`
+	}
+	query := fmt.Sprintf("SELECT id,user_type,password,is_banned FROM %s WHERE phone_number = $1 AND is_activated = $2", userTable)
+
+	err := u.db.Get(&input, query, phone, true)
 
 	if err != nil {
 		return nil, fmt.Errorf("repository.SignIn: %w", domain.ErrUserDoesNotExist)
 	}
 
-	return &input, nil
+	if input.IsBanned {
+		return nil, fmt.Errorf("repository.SignIn: %w", domain.ErrUserBanned)
+	}
+
+	return &input.User, nil
 }
 
-func (u *UserAuthRepos) SetSession(userId int, session domain.Session) error {
+func (u *UserAuthRepos) CreateSession(session domain.Session) (int, error) {
+	var id int
+
+	query := fmt.Sprintf(
+		`INSERT INTO
+					%s
+				(user_id, refresh_token, expires_at, device_name, ip, user_agent)
+					VALUES
+				($1,$2,$3,$4,$5,$6) RETURNING id`, sessionTable)
+
+	err := u.db.QueryRowx(query, session.UserId, session.RefreshToken, session.ExpiresAt, session.DeviceName, session.Ip, session.UserAgent).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("repository.CreateSession: %w", err)
+	}
+
+	return id, nil
+}
 
-	setValues := make([]string, 0, reflect.TypeOf(domain.Session{}).NumField())
+func (u *UserAuthRepos) GetSessions(userId int) ([]*domain.Session, error) {
+	inp := make([]*domain.Session, 0)
+
+	query := fmt.Sprintf(
+		`SELECT
+					id,
+					expires_at,
+					device_name,
+					ip,
+					user_agent,
+					created_at,
+					last_used_at
+				FROM
+					%s
+				WHERE
+					user_id = $1 AND refresh_token != '' AND expires_at > now()
+				ORDER BY
+					last_used_at DESC`, sessionTable)
+
+	err := u.db.Select(&inp, query, userId)
 
-	if session.RefreshToken != "" {
-		setValues = append(setValues, fmt.Sprintf("refresh_token=:refresh_token"))
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetSessions: %w", err)
 	}
 
-	if !session.ExpiresAt.IsZero() {
-		setValues = append(setValues, fmt.Sprintf("expires_at=:expires_at"))
+	return inp, nil
+}
+
+func (u *UserAuthRepos) GetSessionByRefreshToken(refreshToken string) (*domain.Session, error) {
+	var session domain.Session
+
+	query := fmt.Sprintf(
+		`SELECT
+					s.id,
+					s.user_id,
+					s.refresh_token,
+					s.expires_at,
+					u.user_type
+				FROM
+					%s s
+				INNER JOIN
+					%s u
+				ON
+					s.user_id = u.id
+				WHERE
+					s.refresh_token = $1 AND u.is_activated = $2 AND u.is_banned = false`, sessionTable, userTable)
+
+	err := u.db.Get(&session, query, refreshToken, true)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetSessionByRefreshToken: %w", domain.ErrInvalidRefreshToken)
 	}
 
-	setQuery := strings.Join(setValues, ", ")
+	return &session, nil
+}
 
-	if setQuery == "" {
-		return fmt.Errorf("repository.SetSession: %v", "empty body")
+func (u *UserAuthRepos) GetSessionByRotatedToken(refreshToken string) (*domain.Session, error) {
+	var session domain.Session
+
+	query := fmt.Sprintf(
+		`SELECT
+					s.id,
+					s.user_id
+				FROM
+					%s rt
+				INNER JOIN
+					%s s
+				ON
+					rt.session_id = s.id
+				WHERE
+					rt.refresh_token = $1`, rotatedTokenTable, sessionTable)
+
+	err := u.db.Get(&session, query, refreshToken)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetSessionByRotatedToken: %w", domain.ErrInvalidRefreshToken)
 	}
 
-	query := fmt.Sprintf("UPDATE %s SET %s WHERE user_id=%d", sessionTable, setQuery, userId)
+	return &session, nil
+}
+
+func (u *UserAuthRepos) RotateSession(id int, oldRefreshToken string, session domain.Session) error {
+
+	tx := u.db.MustBegin()
+
+	query := fmt.Sprintf(
+		`UPDATE
+					%s
+				SET
+					refresh_token = $1, expires_at = $2, ip = $3, user_agent = $4, last_used_at = now()
+				WHERE
+					id = $5 AND refresh_token = $6`, sessionTable)
 
-	result, err := u.db.NamedExec(query, session)
+	result, err := tx.Exec(query, session.RefreshToken, session.ExpiresAt, session.Ip, session.UserAgent, id, oldRefreshToken)
 
 	if err != nil {
-		return fmt.Errorf("repository.SetSession: %w", err)
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.RotateSession: %w", txErr)
+		}
+		return fmt.Errorf("repository.RotateSession: %w", err)
 	}
 
 	affected, err := result.RowsAffected()
 
+	if err != nil || affected == 0 {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.RotateSession: %w", txErr)
+		}
+		return fmt.Errorf("repository.RotateSession: %w", domain.ErrInvalidRefreshToken)
+	}
+
+	queryInsert := fmt.Sprintf("INSERT INTO %s(session_id, refresh_token) VALUES($1,$2)", rotatedTokenTable)
+
+	_, err = tx.Exec(queryInsert, id, oldRefreshToken)
+
+	if err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.RotateSession: %w", txErr)
+		}
+		return fmt.Errorf("repository.RotateSession: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (u *UserAuthRepos) RevokeSession(id, userId int) error {
+
+	query := fmt.Sprintf("UPDATE %s SET refresh_token = '', expires_at = now() WHERE id = $1 AND user_id = $2 AND refresh_token != ''", sessionTable)
+
+	result, err := u.db.Exec(query, id, userId)
+
+	if err != nil {
+		return fmt.Errorf("repository.RevokeSession: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("repository.SetSession: %w", err)
+		return fmt.Errorf("repository.RevokeSession: %w", err)
 	}
 
 	if affected == 0 {
-		// fmt.Errorf
-		return sql.ErrNoRows
+		return fmt.Errorf("repository.RevokeSession: %w", domain.ErrNotFound)
 	}
 
 	return nil
 }
 
-func (u *UserAuthRepos) VerifyViaPassword(id int, password string) error {
-	var input domain.User
+func (u *UserAuthRepos) RevokeAllSessions(userId int) ([]int, error) {
+	ids := make([]int, 0)
+
+	query := fmt.Sprintf("UPDATE %s SET refresh_token = '', expires_at = now() WHERE user_id = $1 AND refresh_token != '' RETURNING id", sessionTable)
+
+	err := u.db.Select(&ids, query, userId)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.RevokeAllSessions: %w", err)
+	}
+
+	return ids, nil
+}
 
-	query := fmt.Sprintf("SELECT user_name FROM %s WHERE password = $1 AND id = $2", userTable)
-	err := u.db.Get(&input, query, password, id)
+func (u *UserAuthRepos) GetPassword(id int) (string, error) {
+	var password string
+
+	query := fmt.Sprintf("SELECT password FROM %s WHERE id = $1", userTable)
+	err := u.db.QueryRowx(query, id).Scan(&password)
 	if err != nil {
 
-		return fmt.Errorf("repository.VerifyViaPassword: %w", domain.ErrInvalidPassword)
+		return "", fmt.Errorf("repository.GetPassword: %w", domain.ErrInvalidPassword)
 	}
-	return nil
+	return password, nil
 }
 
 func (u *UserAuthRepos) VerifyViaPhoneNumber(phone string) (*domain.User, error) {
@@ -276,10 +467,10 @@ func (u *UserAuthRepos) ResetPassword(phone, password string) error {
 
 }
 
-func (u *UserAuthRepos) SetPassword(id int, hashedOldPassword, hashedNewPassword string) error {
-	query := fmt.Sprintf("UPDATE %s SET password = $1 WHERE password = $2 AND id = $3", userTable)
+func (u *UserAuthRepos) SetPassword(id int, hashedPassword string) error {
+	query := fmt.Sprintf("UPDATE %s SET password = $1 WHERE id = $2", userTable)
 
-	_, err := u.db.Exec(query, hashedNewPassword, hashedOldPassword, id)
+	_, err := u.db.Exec(query, hashedPassword, id)
 
 	if err != nil {
 		return fmt.Errorf("repository.SetPassword: %w", err)
@@ -287,3 +478,35 @@ func (u *UserAuthRepos) SetPassword(id int, hashedOldPassword, hashedNewPassword
 	return nil
 
 }
+
+// SetManagerProfile stores the business details of a signing up manager and puts the account back into review.
+func (u *UserAuthRepos) SetManagerProfile(userId int, business domain.ManagerBusiness, language string) error {
+	query := fmt.Sprintf(
+		`INSERT INTO
+					%s
+				(user_id, legal_name, tax_id, contact_name, contact_phone, contact_email, language)
+					VALUES
+				($1,$2,$3,$4,$5,$6,$7)
+				ON CONFLICT (user_id) DO UPDATE SET
+					legal_name = excluded.legal_name,
+					tax_id = excluded.tax_id,
+					contact_name = excluded.contact_name,
+					contact_phone = excluded.contact_phone,
+					contact_email = excluded.contact_email,
+					language = excluded.language,
+					status = 'pending',
+					reject_reason = '',
+					reviewed_by = NULL,
+					reviewed_at = NULL`, managerTable)
+
+	_, err := u.db.Exec(query, userId, business.LegalName, business.TaxId, business.ContactName, business.ContactPhone, business.ContactEmail, language)
+
+	if err != nil {
+		return fmt.Errorf("repository.SetManagerProfile: %w", err)
+	}
+	return nil
+}
+
+func (u *UserAuthRepos) GetManagerProfile(userId int) (*domain.ManagerProfile, error) {
+	return getManagerProfile(u.db, userId)
+}
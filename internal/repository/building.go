@@ -29,9 +29,9 @@ func (b *BuildingRepos) Create(ctx *fiber.Ctx, building domain.Building) (int, e
 
 	defer cancel()
 
-	query := fmt.Sprintf("INSERT INTO %s(building_name, address, instagram, manager_id,description,building_image,work_time,start_time,end_time,longtitude,latitude) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) RETURNING id", buildingTable)
+	query := fmt.Sprintf("INSERT INTO %s(building_name, address, instagram, manager_id,description,building_image,work_time,start_time,end_time,longtitude,latitude,timezone) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) RETURNING id", buildingTable)
 
-	err := tx.QueryRowx(query, building.Name, building.Address, building.Instagram, building.ManagerId, building.Description, building.BuildingImage, building.WorkTime, secondToTime(building.StartTime), secondToTime(building.EndTime), building.Longtitude, building.Latitude).Scan(&id)
+	err := tx.QueryRowx(query, building.Name, building.Address, building.Instagram, building.ManagerId, building.Description, building.BuildingImage, building.WorkTime, secondToTime(building.StartTime), secondToTime(building.EndTime), building.Longtitude, building.Latitude, building.Timezone).Scan(&id)
 
 	if err != nil {
 		txErr := tx.Rollback()
@@ -41,9 +41,18 @@ func (b *BuildingRepos) Create(ctx *fiber.Ctx, building domain.Building) (int, e
 		return 0, fmt.Errorf("repository.Create: %w", err)
 	}
 
-	for i := building.StartTime; i <= building.EndTime; i = i + 1800 {
-		query := fmt.Sprintf("INSERT INTO %s(work_time, building_id) VALUES($1,$2)", timeTable)
-		_, err := tx.Exec(query, secondToTime(i), id)
+	if building.BuildingImage != "" {
+		if err = replaceCover(tx, domain.ImageOwner{Type: domain.ImageOwnerBuilding, Id: id}, building.BuildingImage); err != nil {
+			txErr := tx.Rollback()
+			if txErr != nil {
+				return 0, fmt.Errorf("repository.Create: %w", txErr)
+			}
+			return 0, fmt.Errorf("repository.Create: %w", err)
+		}
+	}
+
+	for _, day := range domain.DefaultWeek(building.StartTime, building.EndTime, domain.DefaultSlotLength) {
+		err := setBuildingHours(tx, id, day)
 		if err != nil {
 			txErr := tx.Rollback()
 			if txErr != nil {
@@ -73,6 +82,9 @@ func (b *BuildingRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, info doma
 		count            int
 		userId           int
 		userIdCase       string
+		distanceCase     string
+		orderBy          = "b.id ASC"
+		args             []interface{}
 		url              = ctx.BaseURL()
 	)
 
@@ -109,6 +121,32 @@ func (b *BuildingRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, info doma
 		havingValuesList = append(havingValuesList, fmt.Sprintf("min(price) <= %d", *building.EndCost))
 	}
 
+	if building.Q != "" {
+		args = append(args, building.Q)
+
+		whereValuesList = append(whereValuesList, buildingSearchQuery(len(args)))
+		orderBy = fmt.Sprintf("%s DESC, b.id ASC", buildingSearchRank(len(args)))
+	}
+
+	if building.Lat != nil && building.Lng != nil {
+		distance := distanceQuery(*building.Lat, *building.Lng)
+
+		distanceCase = fmt.Sprintf(",%s AS distance", distance)
+
+		if building.Radius != 0 {
+			// the latitude band narrows the search by the index before the exact distance is counted
+			band := building.Radius / metersPerDegree
+
+			whereValuesList = append(whereValuesList,
+				fmt.Sprintf("b.latitude BETWEEN %f AND %f", *building.Lat-band, *building.Lat+band),
+				fmt.Sprintf("%s <= %f", distance, building.Radius))
+		}
+
+		if building.Sort == "distance" {
+			orderBy = "distance ASC, b.id ASC"
+		}
+	}
+
 	whereValuesJoin := strings.Join(whereValuesList, " AND ")
 
 	if whereValuesList != nil {
@@ -116,7 +154,7 @@ func (b *BuildingRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, info doma
 		setValues = setValues + whereClause + whereValuesJoin
 	}
 
-	setValues = setValues + fmt.Sprintf(" GROUP BY b.id,b.building_image, building_name,building_image, address, instagram, manager_id, description, work_time, start_time, end_time,longtitude,latitude,f.user_id,u.phone_number")
+	setValues = setValues + fmt.Sprintf(" GROUP BY b.id,b.building_image, building_name,building_image, address, instagram, manager_id, description, work_time, start_time, end_time,longtitude,latitude,slot_length,min_duration,max_duration,timezone,f.user_id,u.phone_number")
 
 	havingValuesJoin := strings.Join(havingValuesList, " AND ")
 
@@ -148,7 +186,7 @@ func (b *BuildingRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, info doma
 					%s)
 			 SELECT COUNT(*) FROM new_building ;`, buildingTable, pitchTable, favouriteTable, userTable, countValues, havingValuesJoin)
 
-	_ = b.db.QueryRowx(queryCount).Scan(&count)
+	_ = b.db.QueryRowx(queryCount, args...).Scan(&count)
 
 	offset, pagesCount := calculatePagination(&page, count)
 
@@ -159,7 +197,8 @@ func (b *BuildingRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, info doma
 					b.*,
 					u.phone_number,
 					COALESCE(min(price),null,0) as min_price
-					%s	
+					%s
+					%s
 				FROM 
 					%s b
 				LEFT OUTER JOIN 
@@ -176,9 +215,9 @@ func (b *BuildingRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, info doma
 					b.manager_id = u.id
 				%s
 				ORDER BY
-					b.id ASC LIMIT $1 OFFSET $2`, userIdCase, buildingTable, pitchTable, favouriteTable, userId, userTable, setValues)
+					%s LIMIT $%d OFFSET $%d`, userIdCase, distanceCase, buildingTable, pitchTable, favouriteTable, userId, userTable, setValues, orderBy, len(args)+1, len(args)+2)
 
-	err := b.db.Select(&inp, query, page.Limit, offset)
+	err := b.db.Select(&inp, query, append(args, page.Limit, offset)...)
 
 	if err != nil {
 		return nil, fmt.Errorf("repository.GetAll: %w", err)
@@ -267,7 +306,7 @@ func (b *BuildingRepos) GetById(ctx *fiber.Ctx, info domain.UserInfo, id int) (*
 				WHERE 
 					b.id = $1
 				GROUP BY 
-					b.id, building_name,building_image, address, instagram, manager_id, description, work_time, start_time, end_time, longtitude, latitude,f.user_id,u.phone_number;`, userIdCase, buildingTable, pitchTable, favouriteTable, userTable)
+					b.id, building_name,building_image, address, instagram, manager_id, description, work_time, start_time, end_time, longtitude, latitude, slot_length, min_duration, max_duration, timezone,f.user_id,u.phone_number;`, userIdCase, buildingTable, pitchTable, favouriteTable, userTable)
 
 	err := b.db.Get(&inp, query, id)
 
@@ -277,10 +316,18 @@ func (b *BuildingRepos) GetById(ctx *fiber.Ctx, info domain.UserInfo, id int) (*
 		return nil, fmt.Errorf("repository.GetById: %w", domain.ErrNotFound)
 	}
 
+	inp.Images, err = getImages(b.db, url, domain.ImageOwner{Type: domain.ImageOwnerBuilding, Id: id})
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetById: %w", err)
+	}
+
 	return &inp, nil
 }
 
-func (b *BuildingRepos) Update(ctx *fiber.Ctx, id int, inp domain.Building) ([]string, error) {
+// Update changes the building, rewrites the weekly hours with days and cancels the orders resolve
+// picks in one transaction, resolve is left nil when the schedule does not change.
+func (b *BuildingRepos) Update(ctx *fiber.Ctx, id int, inp domain.Building, days []domain.BuildingHours, resolve ScheduleResolver) ([]string, error) {
 
 	setValues := make([]string, 0, reflect.TypeOf(domain.Building{}).NumField())
 
@@ -302,6 +349,25 @@ func (b *BuildingRepos) Update(ctx *fiber.Ctx, id int, inp domain.Building) ([]s
 		images = append(images, "building_image")
 	}
 
+	if inp.Latitude != nil && inp.Longtitude != nil {
+		setValues = append(setValues, "latitude=:latitude", "longtitude=:longtitude")
+	}
+
+	if inp.WorkTime != 0 {
+		setValues = append(setValues, "work_time=:work_time")
+	}
+
+	if inp.Timezone != "" {
+		setValues = append(setValues, "timezone=:timezone")
+	}
+
+	// the weekly hours are rebuilt from these by the service
+	if inp.EndTime != 0 {
+		inp.StartTimeString, inp.EndTimeString = secondToTime(inp.StartTime), secondToTime(inp.EndTime)
+
+		setValues = append(setValues, "start_time=:start_time", "end_time=:end_time")
+	}
+
 	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
 
 	defer cancel()
@@ -324,23 +390,60 @@ func (b *BuildingRepos) Update(ctx *fiber.Ctx, id int, inp domain.Building) ([]s
 		return nil, fmt.Errorf("repository.Update: %w", errors.New("empty body"))
 	}
 
-	query := fmt.Sprintf("UPDATE %s SET %s WHERE id = %d AND manager_id = %d", buildingTable, setQuery, id, inp.ManagerId)
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE id = %d", buildingTable, setQuery, id)
+
+	// the updated row stays locked, no order is placed while the upcoming ones are checked
+	tx := b.db.MustBegin()
 
-	result, err := b.db.NamedExec(query, inp)
+	result, err := tx.NamedExec(query, inp)
 
 	if err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return nil, fmt.Errorf("repository.Update: %w", txErr)
+		}
 		return nil, fmt.Errorf("repository.Update: %w", err)
 	}
 
 	affected, err := result.RowsAffected()
-	if err != nil {
-		return nil, fmt.Errorf("repository.Update: %w", err)
-	}
 
-	if affected == 0 {
+	if err != nil || affected == 0 {
+		if txErr := tx.Rollback(); txErr != nil {
+			return nil, fmt.Errorf("repository.Update: %w", txErr)
+		}
 		return nil, fmt.Errorf("repository.Update: %w", domain.ErrNotFound)
 	}
 
+	if inp.BuildingImage != "" {
+		if err = replaceCover(tx, domain.ImageOwner{Type: domain.ImageOwnerBuilding, Id: id}, inp.BuildingImage); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return nil, fmt.Errorf("repository.Update: %w", txErr)
+			}
+			return nil, fmt.Errorf("repository.Update: %w", err)
+		}
+	}
+
+	for _, day := range days {
+		if err = setBuildingHours(tx, id, day); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return nil, fmt.Errorf("repository.Update: %w", txErr)
+			}
+			return nil, fmt.Errorf("repository.Update: %w", err)
+		}
+	}
+
+	if resolve != nil {
+		if err = resolveOrders(tx, resolve); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return nil, fmt.Errorf("repository.Update: %w", txErr)
+			}
+			return nil, fmt.Errorf("repository.Update: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("repository.Update: %w", err)
+	}
+
 	return images, nil
 }
 
@@ -354,17 +457,34 @@ func (b *BuildingRepos) Delete(ctx *fiber.Ctx, id int) ([]string, error) {
 
 	defer cancel()
 
+	gallery, err := galleryFiles(b.db, domain.ImageOwner{Type: domain.ImageOwnerBuilding, Id: id})
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.Delete: %w", err)
+	}
+
 	query := fmt.Sprintf("DELETE FROM %s WHERE id=$1 RETURNING building_image", buildingTable)
 
-	err := b.db.QueryRowx(query, id).Scan(&image)
+	err = b.db.QueryRowx(query, id).Scan(&image)
 
 	if err != nil {
 		return nil, fmt.Errorf("repository.Delete: %w", err)
 	}
 	images = append(images, image)
+	images = append(images, gallery...)
 	return images, nil
 }
 
+// distanceQuery is the haversine distance in meters from the point to the building,
+// it is NULL for buildings without coordinates.
+func distanceQuery(lat, lng float64) string {
+	return fmt.Sprintf(
+		`(%f * 2 * asin(least(1, sqrt(
+						power(sin(radians(b.latitude - %f) / 2), 2) +
+						cos(radians(%f)) * cos(radians(b.latitude)) * power(sin(radians(b.longtitude - %f) / 2), 2)))))`,
+		earthRadius, lat, lat, lng)
+}
+
 func countPage(db *sqlx.DB, table, setValues string) (int, error) {
 
 	var count int
@@ -59,14 +59,14 @@ func (c *CommentRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, buildingId
 	query := fmt.Sprintf(
 		`SELECT
 					com.id,
-					u.user_name,
+					coalesce(u.user_name, '') "user_name",
 					building_id,
 					comment,
 					grade,
 					extract(epoch from post_data::timestamp at time zone 'GMT') "post_data"
 				FROM 
 					%s com  
-				INNER JOIN 
+				LEFT JOIN 
 					%s u
 				ON 
 					com.user_id = u.id
@@ -82,7 +82,8 @@ func (f *FavouriteRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, userId i
     				address, instagram,
 					manager_id, description,
     				work_time, start_time,
-    				end_time, longtitude, latitude,f.id, f.user_id, u.phone_number`, buildingTable, pitchTable, favouriteTable, userTable)
+    				end_time, longtitude, latitude,
+					slot_length, min_duration, max_duration, timezone,f.id, f.user_id, u.phone_number`, buildingTable, pitchTable, favouriteTable, userTable)
 
 	err := f.db.Select(&inp, query, userId)
 
@@ -165,7 +166,8 @@ func (f *FavouriteRepos) GetById(ctx *fiber.Ctx, id, userId int) (*domain.Buildi
     				address, instagram,
 					manager_id, description,
     				work_time, start_time,
-    				end_time, longtitude, latitude,f.id, f.user_id, u.phone_number`, favouriteTable, buildingTable, pitchTable, userTable)
+    				end_time, longtitude, latitude,
+					slot_length, min_duration, max_duration, timezone,f.id, f.user_id, u.phone_number`, favouriteTable, buildingTable, pitchTable, userTable)
 
 	err := f.db.Get(&inp, query, userId, id)
 
@@ -0,0 +1,330 @@
+package repository
+
+import (
+	"carWash/internal/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type GalleryRepos struct {
+	db *sqlx.DB
+}
+
+func NewGalleryRepos(db *sqlx.DB) *GalleryRepos {
+	return &GalleryRepos{db: db}
+}
+
+// imageOwnerColumns returns the images column pointing to the owner, the owner table
+// and its column that keeps the cover.
+func imageOwnerColumns(owner domain.ImageOwner) (string, string, string) {
+	if owner.Type == domain.ImageOwnerPitch {
+		return "pitch_id", pitchTable, "pitch_image"
+	}
+	return "building_id", buildingTable, "building_image"
+}
+
+// AddImages appends the images to the end of the gallery, the first one becomes the cover
+// when the gallery has none.
+func (g *GalleryRepos) AddImages(ctx *fiber.Ctx, owner domain.ImageOwner, images []string) ([]*domain.Image, error) {
+	var (
+		position int
+		hasCover bool
+		url      = ctx.BaseURL()
+	)
+
+	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
+
+	defer cancel()
+
+	column, table, coverColumn := imageOwnerColumns(owner)
+
+	tx := g.db.MustBegin()
+
+	// locking the owner keeps positions unique when two uploads run at once
+	queryLock := fmt.Sprintf("SELECT id FROM %s WHERE id = $1 FOR UPDATE", table)
+
+	if err := tx.QueryRowx(queryLock, owner.Id).Scan(&owner.Id); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return nil, fmt.Errorf("repository.AddImages: %w", txErr)
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("repository.AddImages: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("repository.AddImages: %w", err)
+	}
+
+	queryState := fmt.Sprintf("SELECT coalesce(max(position) + 1, 0), coalesce(bool_or(is_cover), false) FROM %s WHERE %s = $1", buildingImageTable, column)
+
+	if err := tx.QueryRowx(queryState, owner.Id).Scan(&position, &hasCover); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return nil, fmt.Errorf("repository.AddImages: %w", txErr)
+		}
+		return nil, fmt.Errorf("repository.AddImages: %w", err)
+	}
+
+	added := make([]*domain.Image, 0, len(images))
+
+	query := fmt.Sprintf("INSERT INTO %s(%s, image, position, is_cover) VALUES($1,$2,$3,$4) RETURNING id", buildingImageTable, column)
+
+	for i, image := range images {
+		inp := domain.Image{Image: image, Position: position + i, IsCover: !hasCover && i == 0}
+
+		if err := tx.QueryRowx(query, owner.Id, inp.Image, inp.Position, inp.IsCover).Scan(&inp.Id); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return nil, fmt.Errorf("repository.AddImages: %w", txErr)
+			}
+			return nil, fmt.Errorf("repository.AddImages: %w", err)
+		}
+
+		if inp.IsCover {
+			queryCover := fmt.Sprintf("UPDATE %s SET %s = $1 WHERE id = $2", table, coverColumn)
+
+			if _, err := tx.Exec(queryCover, inp.Image, owner.Id); err != nil {
+				if txErr := tx.Rollback(); txErr != nil {
+					return nil, fmt.Errorf("repository.AddImages: %w", txErr)
+				}
+				return nil, fmt.Errorf("repository.AddImages: %w", err)
+			}
+		}
+
+		inp.Image = url + "/" + "media/" + inp.Image
+
+		added = append(added, &inp)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("repository.AddImages: %w", err)
+	}
+
+	return added, nil
+}
+
+func (g *GalleryRepos) GetImages(ctx *fiber.Ctx, owner domain.ImageOwner) ([]*domain.Image, error) {
+	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
+
+	defer cancel()
+
+	inp, err := getImages(g.db, ctx.BaseURL(), owner)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetImages: %w", err)
+	}
+
+	return inp, nil
+}
+
+// SetImagesOrder gives the images the positions of their ids in the list, the list
+// has to hold the whole gallery.
+func (g *GalleryRepos) SetImagesOrder(ctx *fiber.Ctx, owner domain.ImageOwner, ids []int) error {
+	var count int
+
+	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
+
+	defer cancel()
+
+	column, _, _ := imageOwnerColumns(owner)
+
+	tx := g.db.MustBegin()
+
+	queryCount := fmt.Sprintf("SELECT count(*) FROM %s WHERE %s = $1", buildingImageTable, column)
+
+	if err := tx.QueryRowx(queryCount, owner.Id).Scan(&count); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.SetImagesOrder: %w", txErr)
+		}
+		return fmt.Errorf("repository.SetImagesOrder: %w", err)
+	}
+
+	if count != len(ids) {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.SetImagesOrder: %w", txErr)
+		}
+		return fmt.Errorf("repository.SetImagesOrder: %w", domain.ErrImageOrder)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET position = $1 WHERE id = $2 AND %s = $3", buildingImageTable, column)
+
+	for position, id := range ids {
+		result, err := tx.Exec(query, position, id, owner.Id)
+
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return fmt.Errorf("repository.SetImagesOrder: %w", txErr)
+			}
+			return fmt.Errorf("repository.SetImagesOrder: %w", err)
+		}
+
+		// an id of another gallery or the same id twice leaves some image without a position
+		if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+			if txErr := tx.Rollback(); txErr != nil {
+				return fmt.Errorf("repository.SetImagesOrder: %w", txErr)
+			}
+			return fmt.Errorf("repository.SetImagesOrder: %w", domain.ErrImageOrder)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SetCover moves the cover mark to the image and copies it into the owner row.
+func (g *GalleryRepos) SetCover(ctx *fiber.Ctx, owner domain.ImageOwner, imageId int) error {
+	var image string
+
+	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
+
+	defer cancel()
+
+	column, table, coverColumn := imageOwnerColumns(owner)
+
+	tx := g.db.MustBegin()
+
+	queryReset := fmt.Sprintf("UPDATE %s SET is_cover = false WHERE %s = $1 AND is_cover AND id <> $2", buildingImageTable, column)
+
+	if _, err := tx.Exec(queryReset, owner.Id, imageId); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.SetCover: %w", txErr)
+		}
+		return fmt.Errorf("repository.SetCover: %w", err)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET is_cover = true WHERE id = $1 AND %s = $2 RETURNING image", buildingImageTable, column)
+
+	if err := tx.QueryRowx(query, imageId, owner.Id).Scan(&image); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.SetCover: %w", txErr)
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("repository.SetCover: %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("repository.SetCover: %w", err)
+	}
+
+	queryOwner := fmt.Sprintf("UPDATE %s SET %s = $1 WHERE id = $2", table, coverColumn)
+
+	if _, err := tx.Exec(queryOwner, image, owner.Id); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.SetCover: %w", txErr)
+		}
+		return fmt.Errorf("repository.SetCover: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// DeleteImage removes the image from the gallery and returns its file name, the cover
+// can only be removed after another image is made the cover.
+func (g *GalleryRepos) DeleteImage(ctx *fiber.Ctx, owner domain.ImageOwner, imageId int) (string, error) {
+	var inp domain.Image
+
+	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
+
+	defer cancel()
+
+	column, _, _ := imageOwnerColumns(owner)
+
+	querySelect := fmt.Sprintf("SELECT id, image, position, is_cover FROM %s WHERE id = $1 AND %s = $2", buildingImageTable, column)
+
+	if err := g.db.Get(&inp, querySelect, imageId, owner.Id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("repository.DeleteImage: %w", domain.ErrNotFound)
+		}
+		return "", fmt.Errorf("repository.DeleteImage: %w", err)
+	}
+
+	if inp.IsCover {
+		return "", fmt.Errorf("repository.DeleteImage: %w", domain.ErrCoverImage)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND NOT is_cover", buildingImageTable)
+
+	result, err := g.db.Exec(query, imageId)
+	if err != nil {
+		return "", fmt.Errorf("repository.DeleteImage: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return "", fmt.Errorf("repository.DeleteImage: %w", err)
+	}
+
+	if affected == 0 {
+		return "", fmt.Errorf("repository.DeleteImage: %w", domain.ErrCoverImage)
+	}
+
+	return inp.Image, nil
+}
+
+func getImages(db sqlx.Queryer, url string, owner domain.ImageOwner) ([]*domain.Image, error) {
+	inp := make([]*domain.Image, 0)
+
+	column, _, _ := imageOwnerColumns(owner)
+
+	query := fmt.Sprintf("SELECT id, image, position, is_cover FROM %s WHERE %s = $1 ORDER BY position ASC, id ASC", buildingImageTable, column)
+
+	if err := sqlx.Select(db, &inp, query, owner.Id); err != nil {
+		return nil, err
+	}
+
+	for _, val := range inp {
+		val.Image = url + "/" + "media/" + val.Image
+	}
+
+	return inp, nil
+}
+
+// replaceCover points the cover of the gallery to the new owner image, a gallery without
+// a cover gets one.
+func replaceCover(db sqlx.Execer, owner domain.ImageOwner, image string) error {
+	column, _, _ := imageOwnerColumns(owner)
+
+	query := fmt.Sprintf("UPDATE %s SET image = $1 WHERE %s = $2 AND is_cover", buildingImageTable, column)
+
+	result, err := db.Exec(query, image, owner.Id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected != 0 {
+		return nil
+	}
+
+	queryInsert := fmt.Sprintf(
+		`INSERT INTO %s(%s, image, position, is_cover)
+					SELECT $1, $2, coalesce(min(position) - 1, 0), true FROM %s WHERE %s = $1`,
+		buildingImageTable, column, buildingImageTable, column)
+
+	_, err = db.Exec(queryInsert, owner.Id, image)
+
+	return err
+}
+
+// galleryFiles returns the files of the galleries that go away with the owner, a building
+// takes the galleries of its pitches with it. The owner cover is left out since it is
+// removed through building_image or pitch_image.
+func galleryFiles(db sqlx.Queryer, owner domain.ImageOwner) ([]string, error) {
+	files := make([]string, 0)
+
+	query := fmt.Sprintf("SELECT image FROM %s WHERE pitch_id = $1 AND NOT is_cover", buildingImageTable)
+
+	if owner.Type == domain.ImageOwnerBuilding {
+		query = fmt.Sprintf(
+			`SELECT image FROM %s
+					WHERE (building_id = $1 AND NOT is_cover)
+					   OR pitch_id IN (SELECT id FROM %s WHERE building_id = $1)`, buildingImageTable, pitchTable)
+	}
+
+	if err := sqlx.Select(db, &files, query, owner.Id); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"carWash/internal/domain"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+const managerProfileColumns = `
+					m.user_id,
+					u.user_name,
+					u.phone_number,
+					m.legal_name,
+					m.tax_id,
+					m.contact_name,
+					m.contact_phone,
+					m.contact_email,
+					m.language,
+					m.status,
+					m.reject_reason,
+					m.reviewed_by,
+					extract(epoch from m.reviewed_at::timestamp at time zone 'GMT') "reviewed_at",
+					extract(epoch from m.created_at::timestamp at time zone 'GMT') "created_at"`
+
+func getManagerProfile(db *sqlx.DB, userId int) (*domain.ManagerProfile, error) {
+	var profile domain.ManagerProfile
+
+	query := fmt.Sprintf(
+		`SELECT %s
+				FROM
+					%s m
+				INNER JOIN
+					%s u
+				ON
+					m.user_id = u.id
+				WHERE
+					m.user_id = $1`, managerProfileColumns, managerTable, userTable)
+
+	err := db.Get(&profile, query, userId)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("repository.getManagerProfile: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("repository.getManagerProfile: %w", err)
+	}
+
+	return &profile, nil
+}
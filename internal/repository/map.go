@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"carWash/internal/domain"
+	"context"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+// mapPricesQuery is the cheapest pitch of every building, joined instead of grouping the
+// buildings since their id is not a primary key.
+const mapPricesQuery = `SELECT building_id, min(price) AS min_price FROM %s GROUP BY building_id`
+
+func (b *BuildingRepos) GetMapBuildings(ctx *fiber.Ctx, filter domain.MapFilter) ([]*domain.MapBuilding, error) {
+	url := ctx.BaseURL()
+
+	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
+
+	defer cancel()
+
+	bounds, args := mapBoundsQuery(filter)
+
+	query := fmt.Sprintf(
+		`SELECT
+					b.id, b.building_name, b.address, b.building_image, b.latitude, b.longtitude,
+					coalesce(p.min_price, 0) AS min_price
+				FROM
+					%s b
+				LEFT OUTER JOIN
+					(%s) p
+				ON
+					b.id = p.building_id
+				WHERE
+					%s
+				ORDER BY
+					b.id ASC`, buildingTable, fmt.Sprintf(mapPricesQuery, pitchTable), bounds)
+
+	inp := make([]*domain.MapBuilding, 0)
+
+	if err := b.db.Select(&inp, query, args...); err != nil {
+		return nil, fmt.Errorf("repository.GetMapBuildings: %w", err)
+	}
+
+	for _, val := range inp {
+		val.BuildingImage = url + "/" + "media/" + val.BuildingImage
+	}
+
+	return inp, nil
+}
+
+// GetMapClusters groups the buildings by a grid of cell degrees, the cluster is placed
+// at the centroid of its buildings rather than the middle of the cell.
+func (b *BuildingRepos) GetMapClusters(ctx *fiber.Ctx, filter domain.MapFilter, cell float64) ([]*domain.MapCluster, error) {
+	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
+
+	defer cancel()
+
+	bounds, args := mapBoundsQuery(filter)
+
+	args = append(args, cell)
+
+	query := fmt.Sprintf(
+		`SELECT
+					count(*) AS count,
+					avg(latitude) AS latitude,
+					avg(longtitude) AS longtitude,
+					coalesce(min(min_price), 0) AS min_price,
+					CASE WHEN count(*) = 1 THEN min(id) END AS building_id
+				FROM
+					(SELECT
+						b.id, b.latitude, b.longtitude, p.min_price
+					FROM
+						%s b
+					LEFT OUTER JOIN
+						(%s) p
+					ON
+						b.id = p.building_id
+					WHERE
+						%s) AS visible
+				GROUP BY
+					floor(latitude / $%d), floor(longtitude / $%d)`, buildingTable, fmt.Sprintf(mapPricesQuery, pitchTable), bounds, len(args), len(args))
+
+	inp := make([]*domain.MapCluster, 0)
+
+	if err := b.db.Select(&inp, query, args...); err != nil {
+		return nil, fmt.Errorf("repository.GetMapClusters: %w", err)
+	}
+
+	return inp, nil
+}
+
+func mapBoundsQuery(filter domain.MapFilter) (string, []interface{}) {
+	args := []interface{}{*filter.South, *filter.North, *filter.West, *filter.East}
+
+	if *filter.West > *filter.East {
+		return "b.latitude BETWEEN $1 AND $2 AND (b.longtitude >= $3 OR b.longtitude <= $4)", args
+	}
+
+	return "b.latitude BETWEEN $1 AND $2 AND b.longtitude BETWEEN $3 AND $4", args
+}
@@ -3,14 +3,19 @@ package repository
 import (
 	"carWash/internal/domain"
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx"
 	"github.com/jmoiron/sqlx"
-	"strconv"
 	"strings"
 	"time"
 )
 
+// exclusionViolation is raised by orders_no_overlap when a concurrent order took the interval first.
+const exclusionViolation = "23P01"
+
 type OrderRepos struct {
 	db *sqlx.DB
 }
@@ -19,6 +24,8 @@ func NewOrderRepos(db *sqlx.DB) *OrderRepos {
 	return &OrderRepos{db: db}
 }
 
+// Create stores the order unless its interval overlaps another order of the pitch
+// that is not cancelled, orders_no_overlap keeps concurrent orders apart.
 func (o *OrderRepos) Create(ctx *fiber.Ctx, order domain.Order) (int, error) {
 	var id int
 
@@ -28,38 +35,50 @@ func (o *OrderRepos) Create(ctx *fiber.Ctx, order domain.Order) (int, error) {
 
 	tx := o.db.MustBegin()
 
-	t := order.Times[(len(order.Times) - 1)]
-
-	pt := strings.Split(t, ":")
-
-	hh, err := strconv.Atoi(pt[0])
-
-	if err != nil {
-		return 0, fmt.Errorf("repository.Create:err1 %w", err)
-	}
-
-	min, err := strconv.Atoi(pt[1])
-
-	if err != nil {
-		return 0, fmt.Errorf("repository.Create:err1 %w", err)
+	// a schedule change locks the building, the order waits for it instead of slipping past its check
+	queryLock := fmt.Sprintf(
+		`SELECT
+							b.id
+						FROM
+							%s b
+						JOIN
+							%s p
+						ON
+							p.building_id = b.id
+						WHERE
+							p.id = $1
+						FOR SHARE OF b`, buildingTable, pitchTable)
+
+	if _, err := tx.Exec(queryLock, order.PitchId); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return 0, fmt.Errorf("repository.Create: %w", txErr)
+		}
+		return 0, fmt.Errorf("repository.Create: %w", err)
 	}
 
-	total := hh*3600 + min*60
-
 	query := fmt.Sprintf(
 		`INSERT INTO
 							%s
-						(first_name, phone_number, extra_info, card_id, pitch_id, user_id, order_date, status,end_order_date) 
-							VALUES
-						($1,$2,$3,$4,$5,$6,to_timestamp($7) at time zone 'GMT',$8,to_timestamp($9) at time zone 'GMT') RETURNING id`, orderTable)
+						(first_name, phone_number, extra_info, card_id, pitch_id, user_id, order_date, status, start_order_date, end_order_date) 
+							SELECT
+						$1,$2,$3,NULLIF($4, 0),$5,$6,to_timestamp($7),$8,to_timestamp($9),to_timestamp($10)
+							WHERE NOT EXISTS (
+								SELECT 1 FROM %s WHERE pitch_id = $5 AND status <> $11
+									AND start_order_date < to_timestamp($10)
+									AND end_order_date > to_timestamp($9)
+							) RETURNING id`, orderTable, orderTable)
 
-	err = tx.QueryRowx(query, order.UserName, order.PhoneNumber, order.ExtraInfo, order.CardId, order.PitchId, order.UserId, order.OrderDate, order.Status, total+int(order.OrderDate)).Scan(&id)
+	err := tx.QueryRowx(query, order.UserName, order.PhoneNumber, order.ExtraInfo, order.CardId, order.PitchId, order.UserId, order.OrderDate, order.Status, order.StartDate, order.EndDate, domain.OrderCancelled).Scan(&id)
 
 	if err != nil {
 		txErr := tx.Rollback()
 		if txErr != nil {
 			return 0, fmt.Errorf("repository.Create:tx1 %w", txErr)
 		}
+		var pgErr pgx.PgError
+		if errors.Is(err, sql.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == exclusionViolation) {
+			return 0, fmt.Errorf("repository.Create: %w", domain.ErrTimeBooked)
+		}
 		return 0, fmt.Errorf("repository.Create:err1 %w", err)
 	}
 
@@ -81,24 +100,6 @@ func (o *OrderRepos) Create(ctx *fiber.Ctx, order domain.Order) (int, error) {
 			return 0, fmt.Errorf("repository.Create:err2 %w", err)
 		}
 
-	}
-	for _, val := range order.Times {
-		queryService := fmt.Sprintf(
-			`INSERT INTO
-							%s
-						(order_work_time,order_id) 
-							VALUES
-						($1,$2)`, orderTimeTable)
-
-		_, err = tx.Exec(queryService, val, id)
-		if err != nil {
-			txErr := tx.Rollback()
-			if txErr != nil {
-				return 0, fmt.Errorf("repository.Create:tx3 %w", txErr)
-			}
-			return 0, fmt.Errorf("repository.Create:err3 %w", err)
-		}
-
 	}
 
 	txErr := tx.Commit()
@@ -108,6 +109,52 @@ func (o *OrderRepos) Create(ctx *fiber.Ctx, order domain.Order) (int, error) {
 	return id, nil
 }
 
+func (o *OrderRepos) GetById(ctx *fiber.Ctx, id int) (*domain.Order, error) {
+	var inp domain.Order
+
+	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
+
+	defer cancel()
+
+	query := fmt.Sprintf(
+		`select 
+					o.id,
+					coalesce(o.user_id, 0) "user_id",
+					extract(epoch from o.order_date) "order_date",
+					extract(epoch from o.start_order_date) "start_order_date",
+					extract(epoch from o.end_order_date) "end_order_date",
+					o.status,
+					o.first_name,
+					o.phone_number,
+					coalesce(o.card_id, 0) "card_id",
+					p.price,
+					p.pitch_type,
+					p.pitch_extra,
+					p.pitch_image,
+					b.building_name,
+					b.address,
+					b.timezone
+				from 
+					%s o 
+				LEFT OUTER JOIN
+					%s p 
+				on 
+					p.id = o.pitch_id
+				LEFT OUTER JOIN
+					%s b
+				on 
+					b.id = p.building_id
+				WHERE o.id = $1`, orderTable, pitchTable, buildingTable)
+
+	if err := o.db.Get(&inp, query, id); err != nil {
+		return nil, fmt.Errorf("repository.GetById: %w", domain.ErrNotFound)
+	}
+
+	inp.SetLocalTimes()
+
+	return &inp, nil
+}
+
 func (o *OrderRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, info domain.UserInfo, order domain.FilterForOrder) (*domain.GetAllResponses, error) {
 
 	var (
@@ -126,19 +173,23 @@ func (o *OrderRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, info domain.
 	case "manager":
 		forCheckValues = append(forCheckValues, fmt.Sprintf("b.manager_id = %d", info.Id))
 
-	case "user":
+	case "user", "guest":
 		forCheckValues = append(forCheckValues, fmt.Sprintf("o.user_id = %d", info.Id))
 	}
 
 	if order.OrderDate != 0 {
-		forCheckValues = append(forCheckValues, fmt.Sprintf("o.order_date = to_timestamp(%f) at time zone 'GMT'", order.OrderDate))
+		forCheckValues = append(forCheckValues, fmt.Sprintf("o.order_date = to_timestamp(%f)", order.OrderDate))
+	}
+
+	if order.BuildingId != 0 {
+		forCheckValues = append(forCheckValues, fmt.Sprintf("b.id = %d", order.BuildingId))
 	}
 
 	switch order.OrderStatus {
 	case 1:
-		forCheckValues = append(forCheckValues, fmt.Sprintf("o.end_order_date < to_timestamp(%d) at time zone 'GMT'", time.Now().Unix()))
+		forCheckValues = append(forCheckValues, fmt.Sprintf("o.end_order_date < to_timestamp(%d)", time.Now().Unix()))
 	case 2:
-		forCheckValues = append(forCheckValues, fmt.Sprintf("o.end_order_date >= to_timestamp(%d) at time zone 'GMT'", time.Now().Unix()))
+		forCheckValues = append(forCheckValues, fmt.Sprintf("o.end_order_date >= to_timestamp(%d)", time.Now().Unix()))
 	}
 
 	whereClause = strings.Join(forCheckValues, " AND ")
@@ -177,17 +228,20 @@ func (o *OrderRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, info domain.
 	query := fmt.Sprintf(
 		`select 
 					o.id,
-					extract(epoch from o.order_date::timestamp at time zone 'GMT') "order_date",
+					extract(epoch from o.order_date) "order_date",
+					extract(epoch from o.start_order_date) "start_order_date",
+					extract(epoch from o.end_order_date) "end_order_date",
 					o.status,
 					o.first_name,
 					o.phone_number,
-					o.card_id,
+					coalesce(o.card_id, 0) "card_id",
 					p.price,
 					p.pitch_type,
 					p.pitch_extra,
 					p.pitch_image,
 					b.building_name,
-					b.address
+					b.address,
+					b.timezone
 				from 
 					%s o 
 				LEFT OUTER JOIN
@@ -214,15 +268,7 @@ func (o *OrderRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, info domain.
 
 	for _, value := range inp {
 		value.PitchImage = url + "/" + "media/" + value.PitchImage
-
-		queryTimes := fmt.Sprintf("SELECT id,order_work_time FROM %s WHERE order_id = $1 ", orderTimeTable)
-
-		err = o.db.Select(&value.TimeInput, queryTimes, value.Id)
-
-		if err != nil {
-			return nil, fmt.Errorf("repository.GetAll: %w", err)
-
-		}
+		value.SetLocalTimes()
 
 		queryServices := fmt.Sprintf(
 			`SELECT
@@ -252,74 +298,31 @@ func (o *OrderRepos) GetAll(ctx *fiber.Ctx, page domain.Pagination, info domain.
 	return &ans, nil
 }
 
-func (o *OrderRepos) GetAllBookTime(ctx *fiber.Ctx, times domain.FilterForOrderTimes) (*domain.GetAllResponses, error) {
-	var (
-		forCheckValues []string
-		whereClause    string
-		setValues      string
-		caseValue      string
-	)
-
+// GetBookedIntervals returns the orders of the pitch that overlap the period from and to,
+// both are unix seconds.
+func (o *OrderRepos) GetBookedIntervals(ctx *fiber.Ctx, pitchId int, from, to float64) ([]*domain.BookedInterval, error) {
 	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
 
 	defer cancel()
 
-	if times.OrderDate != 0 {
-		caseValue = fmt.Sprintf(
-			`,CASE
-						WHEN ot.id != 0 THEN true
-						ELSE false
-					END is_booked`)
-
-		//forCheckValues = append(forCheckValues, fmt.Sprintf("o.order_date = to_timestamp(%f) at time zone 'GMT'", times.OrderDate))
-
-	}
-	if times.BuildingId != 0 {
-		forCheckValues = append(forCheckValues, fmt.Sprintf("t.building_id = %d", times.BuildingId))
-	}
-
-	whereClause = strings.Join(forCheckValues, " AND ")
-
-	if len(forCheckValues) != 0 {
-		setValues = " WHERE " + whereClause
-	}
-
-	var inp []*domain.OrderTime
+	inp := make([]*domain.BookedInterval, 0)
 
 	query := fmt.Sprintf(
-		`select
-					t.work_time
+		`SELECT
+					extract(epoch from start_order_date) AS start_order_date,
+					extract(epoch from end_order_date) AS end_order_date
+				FROM
 					%s
-					from
-						%s t
-							LEFT JOIN
-							%s b
-								on
-									b.id = t.building_id
-							LEFT JOIN
-							%s p
-								on
-									(b.id = p.building_id and p.id = %d)
-							LEFT JOIN 
-								%s o
-								on
-									(p.id = o.pitch_id and o.order_date = to_timestamp(%f) at time zone 'GMT')
-							LEFT JOIN 
-								%s ot 
-							on 
-								(o.id = ot.order_id and t.work_time = ot.order_work_time )
-							%s
-					ORDER BY t.id ;`, caseValue, timeTable, buildingTable, pitchTable, times.PitchId, orderTable, times.OrderDate, orderTimeTable, setValues)
-
-	err := o.db.Select(&inp, query)
+				WHERE
+					pitch_id = $1 AND status <> $2
+					AND start_order_date < to_timestamp($4)
+					AND end_order_date > to_timestamp($3)`, orderTable)
 
-	if err != nil {
-		return nil, fmt.Errorf("repository.GetAllBookTime: %w", err)
-	}
-	ans := domain.GetAllResponses{
-		Data: inp,
+	if err := o.db.Select(&inp, query, pitchId, domain.OrderCancelled, from, to); err != nil {
+		return nil, fmt.Errorf("repository.GetBookedIntervals: %w", err)
 	}
-	return &ans, nil
+
+	return inp, nil
 }
 
 func (o *OrderRepos) Delete(ctx *fiber.Ctx, id int) error {
@@ -34,13 +34,20 @@ func (p *PitchRepos) Create(ctx *fiber.Ctx, pitch domain.Pitch) (int, error) {
 						(building_id,price,pitch_image,pitch_type,pitch_extra) 
 					SELECT 
 						b.id, $1, $2 ,$3, $4 
-					FROM %s b INNER JOIN %s u on b.manager_id = u.id WHERE b.id = $5 AND u.id = $6 RETURNING id`, pitchTable, buildingTable, userTable)
+					FROM %s b WHERE b.id = $5 RETURNING id`, pitchTable, buildingTable)
 
-	err := p.db.QueryRowx(query, pitch.Price, pitch.Image, pitch.PitchType, pitch.PitchExtra, pitch.BuildingId, pitch.ManagerId).Scan(&id)
+	err := p.db.QueryRowx(query, pitch.Price, pitch.Image, pitch.PitchType, pitch.PitchExtra, pitch.BuildingId).Scan(&id)
 
 	if err != nil {
 		return 0, fmt.Errorf("repository.Create: %w", err)
 	}
+
+	if pitch.Image != "" {
+		if err = replaceCover(p.db, domain.ImageOwner{Type: domain.ImageOwnerPitch, Id: id}, pitch.Image); err != nil {
+			return 0, fmt.Errorf("repository.Create: %w", err)
+		}
+	}
+
 	return id, nil
 }
 
@@ -120,6 +127,12 @@ func (p *PitchRepos) GetById(ctx *fiber.Ctx, id int) (*domain.Pitch, error) {
 	}
 	place.Image = url + "/" + "media/" + place.Image
 
+	place.Images, err = getImages(p.db, url, domain.ImageOwner{Type: domain.ImageOwnerPitch, Id: id})
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetById: %w", err)
+	}
+
 	return &place, nil
 }
 
@@ -189,6 +202,12 @@ func (p *PitchRepos) Update(ctx *fiber.Ctx, id int, inp domain.Pitch) ([]string,
 		return nil, fmt.Errorf("repository.Update: %w", domain.ErrNotFound)
 	}
 
+	if inp.Image != "" {
+		if err = replaceCover(p.db, domain.ImageOwner{Type: domain.ImageOwnerPitch, Id: id}, inp.Image); err != nil {
+			return nil, fmt.Errorf("repository.Update: %w", err)
+		}
+	}
+
 	return images, nil
 }
 
@@ -203,15 +222,22 @@ func (p *PitchRepos) Delete(ctx *fiber.Ctx, id int) ([]string, error) {
 
 	defer cancel()
 
+	gallery, err := galleryFiles(p.db, domain.ImageOwner{Type: domain.ImageOwnerPitch, Id: id})
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.Delete: %w", err)
+	}
+
 	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 RETURNING pitch_image", pitchTable)
 
-	err := p.db.QueryRowx(query, id).Scan(&image)
+	err = p.db.QueryRowx(query, id).Scan(&image)
 
 	if err != nil {
 		return nil, fmt.Errorf("repository.Delete: %w", domain.ErrNotFound)
 	}
 
 	images = append(images, image)
+	images = append(images, gallery...)
 
 	return images, nil
 }
@@ -5,25 +5,43 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/jmoiron/sqlx"
 	"math"
+	"strings"
+	"time"
 )
 
 const (
-	userTable          = "users"
-	sessionTable       = "sessions"
-	buildingTable      = "buildings"
-	buildingImageTable = "images"
-	pitchTable         = "pitches"
-	favouriteTable     = "favourites"
-	orderTable         = "orders"
-	commentTable       = "comments"
-	gradeTable         = "grades"
-	feedbackTable      = "feedbacks"
-	serviceTable       = "services"
-	cardTable          = "cards"
-	timeTable          = "times"
-	orderServiceTable  = "order_services"
-	orderTimeTable     = "order_times"
-	notificationTable  = "notifications"
+	userTable              = "users"
+	sessionTable           = "sessions"
+	buildingTable          = "buildings"
+	buildingImageTable     = "images"
+	pitchTable             = "pitches"
+	favouriteTable         = "favourites"
+	orderTable             = "orders"
+	commentTable           = "comments"
+	gradeTable             = "grades"
+	feedbackTable          = "feedbacks"
+	serviceTable           = "services"
+	cardTable              = "cards"
+	buildingHoursTable     = "building_hours"
+	buildingExceptionTable = "building_exceptions"
+	orderServiceTable      = "order_services"
+	notificationTable      = "notifications"
+	rotatedTokenTable      = "rotated_tokens"
+	managerTable           = "manager_profiles"
+	roleTable              = "roles"
+	rolePermissionTable    = "role_permissions"
+	buildingStaffTable     = "building_staff"
+	twoFactorTable         = "two_factor"
+	recoveryCodeTable      = "recovery_codes"
+	auditTable             = "audit_log"
+	apiKeyTable            = "api_keys"
+	apiKeyBuildingTable    = "api_key_buildings"
+	apiKeyPermissionTable  = "api_key_permissions"
+)
+
+const (
+	earthRadius     = 6371000.0
+	metersPerDegree = earthRadius * math.Pi / 180
 )
 
 type FavouriteInput struct {
@@ -36,26 +54,50 @@ type UserAuth interface {
 	UpdateUser(user domain.User, id int) error
 	UpdateUserInfo(user domain.UserUpdate, id int) error
 	CreateUser(user domain.User) (int, error)
+	ClaimGuest(id int, name, password string) error
+	DeleteUser(id int) error
 	Verify(phone string) error
 
-	SignIn(phone, password string) (*domain.User, error)
-	SetSession(userId int, session domain.Session) error
+	SignIn(phone string) (*domain.User, error)
+	CreateSession(session domain.Session) (int, error)
+	GetSessions(userId int) ([]*domain.Session, error)
+	GetSessionByRefreshToken(refreshToken string) (*domain.Session, error)
+	GetSessionByRotatedToken(refreshToken string) (*domain.Session, error)
+	RotateSession(id int, oldRefreshToken string, session domain.Session) error
+	RevokeSession(id, userId int) error
+	RevokeAllSessions(userId int) ([]int, error)
 
 	GetUser(id int) (*domain.User, error)
+	SetEmail(userId int, email string) error
+	VerifyEmail(userId int, email string) error
 
-	VerifyViaPassword(id int, password string) error
-	SetPassword(id int, hashedOldPassword, hashedNewPassword string) error
+	GetPassword(id int) (string, error)
+	SetPassword(id int, hashedPassword string) error
 
 	VerifyViaPhoneNumber(phone string) (*domain.User, error)
 	ResetPassword(phone, password string) error
+
+	SetManagerProfile(userId int, business domain.ManagerBusiness, language string) error
+	GetManagerProfile(userId int) (*domain.ManagerProfile, error)
+
+	GetTwoFactor(userId int) (*domain.TwoFactor, error)
+	SetTwoFactorSecret(userId int, secret string) error
+	EnableTwoFactor(userId int, codeHashes []string) error
+	SetRecoveryCodes(userId int, codeHashes []string) error
+	GetRecoveryCodes(userId int) ([]*domain.RecoveryCode, error)
+	UseRecoveryCode(id int) error
+	DeleteTwoFactor(userId int) error
 }
 
 type Building interface {
 	Create(c *fiber.Ctx, building domain.Building) (int, error)
 	GetAll(c *fiber.Ctx, page domain.Pagination, info domain.UserInfo, building domain.FilterForBuilding) (*domain.GetAllResponses, error)
 	GetById(c *fiber.Ctx, info domain.UserInfo, id int) (*domain.Building, error)
-	Update(c *fiber.Ctx, id int, inp domain.Building) ([]string, error)
+	Update(c *fiber.Ctx, id int, inp domain.Building, days []domain.BuildingHours, resolve ScheduleResolver) ([]string, error)
 	Delete(c *fiber.Ctx, id int) ([]string, error)
+	GetMapBuildings(c *fiber.Ctx, filter domain.MapFilter) ([]*domain.MapBuilding, error)
+	GetMapClusters(c *fiber.Ctx, filter domain.MapFilter, cell float64) ([]*domain.MapCluster, error)
+	Suggest(c *fiber.Ctx, q string, limit int) ([]*domain.BuildingSuggestion, error)
 }
 
 type Pitch interface {
@@ -76,7 +118,8 @@ type Favourite interface {
 type Order interface {
 	Create(ctx *fiber.Ctx, order domain.Order) (int, error)
 	GetAll(ctx *fiber.Ctx, page domain.Pagination, info domain.UserInfo, order domain.FilterForOrder) (*domain.GetAllResponses, error)
-	GetAllBookTime(ctx *fiber.Ctx, times domain.FilterForOrderTimes) (*domain.GetAllResponses, error)
+	GetById(ctx *fiber.Ctx, id int) (*domain.Order, error)
+	GetBookedIntervals(ctx *fiber.Ctx, pitchId int, from, to float64) ([]*domain.BookedInterval, error)
 	Delete(ctx *fiber.Ctx, id int) error
 }
 
@@ -109,6 +152,77 @@ type Card interface {
 	Delete(ctx *fiber.Ctx, id, userId int) error
 }
 
+type Admin interface {
+	GetAllUsers(ctx *fiber.Ctx, page domain.Pagination, filter domain.FilterForUser) (*domain.GetAllResponses, error)
+	GetUserById(ctx *fiber.Ctx, id int) (*domain.AdminUser, error)
+	SetRole(ctx *fiber.Ctx, adminId, id int, userType string) error
+	SetBanned(ctx *fiber.Ctx, id int, banned bool, reason string) error
+	GetUserComments(ctx *fiber.Ctx, page domain.Pagination, userId int) (*domain.GetAllResponses, error)
+	GetUserFeedbacks(ctx *fiber.Ctx, page domain.Pagination, userId int) (*domain.GetAllResponses, error)
+
+	GetAllManagers(ctx *fiber.Ctx, page domain.Pagination, filter domain.FilterForManager) (*domain.GetAllResponses, error)
+	GetManagerProfile(ctx *fiber.Ctx, userId int) (*domain.ManagerProfile, error)
+	SetManagerStatus(ctx *fiber.Ctx, userId, reviewerId int, status, reason string) error
+}
+
+type Access interface {
+	GetRoles() ([]*domain.Role, error)
+	RoleExists(roleId int) (bool, error)
+	GetBuildingOwner(buildingId int) (int, error)
+	IsApprovedManager(userId int) (bool, error)
+	GetPitchBuilding(pitchId int) (int, error)
+	GetStaffPermissions(userId, buildingId int) ([]string, error)
+	GetStaff(buildingId int) ([]*domain.Staff, error)
+	SetStaff(buildingId, userId, roleId int) (int, error)
+	RemoveStaff(buildingId, userId int) error
+}
+
+type Audit interface {
+	Create(entry domain.AuditEntry) error
+	GetAll(ctx *fiber.Ctx, page domain.Pagination, filter domain.FilterForAudit) (*domain.GetAllResponses, error)
+	DeleteOlderThan(before time.Time) (int64, error)
+}
+
+type APIKey interface {
+	Create(userId int, name, prefix, keyHash string, buildings []int, permissions []string) (int, error)
+	GetAll(userId int) ([]*domain.APIKey, error)
+	GetByHash(keyHash string) (*domain.APIKey, error)
+	Touch(id int, ip string) error
+	Revoke(id, userId int) error
+}
+
+type Gallery interface {
+	AddImages(c *fiber.Ctx, owner domain.ImageOwner, images []string) ([]*domain.Image, error)
+	GetImages(c *fiber.Ctx, owner domain.ImageOwner) ([]*domain.Image, error)
+	SetImagesOrder(c *fiber.Ctx, owner domain.ImageOwner, ids []int) error
+	SetCover(c *fiber.Ctx, owner domain.ImageOwner, imageId int) error
+	DeleteImage(c *fiber.Ctx, owner domain.ImageOwner, imageId int) (string, error)
+}
+
+// ScheduleReader reads the hours and the upcoming orders of a building.
+type ScheduleReader interface {
+	GetWeek(buildingId int) ([]*domain.BuildingHours, error)
+	GetExceptions(buildingId int, from string) ([]*domain.ScheduleException, error)
+	GetUpcomingOrders(buildingId int, now int64) ([]*domain.ScheduleConflict, error)
+	GetTimezone(buildingId int) (string, error)
+}
+
+// ScheduleResolver runs inside the transaction that changes the schedule with a reader of that
+// transaction and returns the orders to cancel, an error rolls the change back.
+type ScheduleResolver func(schedule ScheduleReader) ([]int, error)
+
+type Schedule interface {
+	ScheduleReader
+	GetDayHours(buildingId int, date string, weekday int) (*domain.BuildingHours, error)
+	GetBookingRules(buildingId int) (*domain.BookingRules, error)
+	GetPitchBookingRules(pitchId int) (*domain.PitchBookingRules, error)
+	SetBookingRules(buildingId int, rules domain.BookingRules) error
+	SetPitchBookingRules(pitchId int, rules domain.PitchBookingRulesInput) error
+	SetWeek(buildingId int, days []domain.BuildingHours, resolve ScheduleResolver) error
+	SetException(buildingId int, exception domain.ScheduleException, resolve ScheduleResolver) (int, error)
+	DeleteException(buildingId, id int) error
+}
+
 type Repository struct {
 	UserAuth
 	Building
@@ -119,6 +233,12 @@ type Repository struct {
 	Feedback
 	FootService
 	Card
+	Admin
+	Access
+	Audit
+	APIKey
+	Gallery
+	Schedule
 }
 
 func NewRepository(db *sqlx.DB) *Repository {
@@ -132,9 +252,18 @@ func NewRepository(db *sqlx.DB) *Repository {
 		Feedback:    NewFeedbackRepos(db),
 		FootService: NewFootServiceRepos(db),
 		Card:        NewCardRepos(db),
+		Admin:       NewAdminRepos(db),
+		Access:      NewAccessRepos(db),
+		Audit:       NewAuditRepos(db),
+		APIKey:      NewAPIKeyRepos(db),
+		Gallery:     NewGalleryRepos(db),
+		Schedule:    NewScheduleRepos(db),
 	}
 }
 
+// likeEscaper makes user input match literally in a LIKE pattern, backslash is the default escape.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 func calculatePagination(page *domain.Pagination, count int) (int, int) {
 	if page.Limit == 0 {
 		page.Limit = count
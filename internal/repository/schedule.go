@@ -0,0 +1,416 @@
+package repository
+
+import (
+	"carWash/internal/domain"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+type ScheduleRepos struct {
+	db *sqlx.DB
+}
+
+func NewScheduleRepos(db *sqlx.DB) *ScheduleRepos {
+	return &ScheduleRepos{db: db}
+}
+
+func (s *ScheduleRepos) GetWeek(buildingId int) ([]*domain.BuildingHours, error) {
+	return getWeek(s.db, buildingId)
+}
+
+// GetExceptions returns the exceptions starting from the date, the past ones do not matter anymore.
+func (s *ScheduleRepos) GetExceptions(buildingId int, from string) ([]*domain.ScheduleException, error) {
+	return getExceptions(s.db, buildingId, from)
+}
+
+// GetUpcomingOrders returns the building orders that are not over yet.
+func (s *ScheduleRepos) GetUpcomingOrders(buildingId int, now int64) ([]*domain.ScheduleConflict, error) {
+	return getUpcomingOrders(s.db, buildingId, now)
+}
+
+func (s *ScheduleRepos) GetTimezone(buildingId int) (string, error) {
+	return getTimezone(s.db, buildingId)
+}
+
+// scheduleTx reads the schedule inside the transaction that changes it.
+type scheduleTx struct {
+	tx *sqlx.Tx
+}
+
+func (s scheduleTx) GetWeek(buildingId int) ([]*domain.BuildingHours, error) {
+	return getWeek(s.tx, buildingId)
+}
+
+func (s scheduleTx) GetExceptions(buildingId int, from string) ([]*domain.ScheduleException, error) {
+	return getExceptions(s.tx, buildingId, from)
+}
+
+func (s scheduleTx) GetUpcomingOrders(buildingId int, now int64) ([]*domain.ScheduleConflict, error) {
+	return getUpcomingOrders(s.tx, buildingId, now)
+}
+
+func (s scheduleTx) GetTimezone(buildingId int) (string, error) {
+	return getTimezone(s.tx, buildingId)
+}
+
+func getWeek(q sqlx.Queryer, buildingId int) ([]*domain.BuildingHours, error) {
+	inp := make([]*domain.BuildingHours, 0)
+
+	query := fmt.Sprintf(
+		`SELECT
+					weekday, is_closed, to_char(open_time, 'HH24:MI') AS open_time, to_char(close_time, 'HH24:MI') AS close_time
+				FROM
+					%s
+				WHERE
+					building_id = $1
+				ORDER BY
+					weekday`, buildingHoursTable)
+
+	if err := sqlx.Select(q, &inp, query, buildingId); err != nil {
+		return nil, fmt.Errorf("repository.GetWeek: %w", err)
+	}
+
+	return inp, nil
+}
+
+func getExceptions(q sqlx.Queryer, buildingId int, from string) ([]*domain.ScheduleException, error) {
+	inp := make([]*domain.ScheduleException, 0)
+
+	query := fmt.Sprintf(
+		`SELECT
+					id, to_char(exception_date, 'YYYY-MM-DD') AS exception_date, is_closed,
+					to_char(open_time, 'HH24:MI') AS open_time, to_char(close_time, 'HH24:MI') AS close_time, reason
+				FROM
+					%s
+				WHERE
+					building_id = $1 AND exception_date >= $2::date
+				ORDER BY
+					exception_date`, buildingExceptionTable)
+
+	if err := sqlx.Select(q, &inp, query, buildingId, from); err != nil {
+		return nil, fmt.Errorf("repository.GetExceptions: %w", err)
+	}
+
+	return inp, nil
+}
+
+// GetDayHours returns the hours of the date, an exception on the date wins over the weekday.
+func (s *ScheduleRepos) GetDayHours(buildingId int, date string, weekday int) (*domain.BuildingHours, error) {
+	var inp domain.BuildingHours
+
+	query := fmt.Sprintf(
+		`SELECT
+					$3 AS weekday,
+					coalesce(e.is_closed, h.is_closed, true) AS is_closed,
+					coalesce(to_char(coalesce(e.open_time, h.open_time), 'HH24:MI'), '') AS open_time,
+					coalesce(to_char(coalesce(e.close_time, h.close_time), 'HH24:MI'), '') AS close_time
+				FROM
+					%s b
+				LEFT JOIN
+					%s h
+				ON
+					(h.building_id = b.id AND h.weekday = $3)
+				LEFT JOIN
+					%s e
+				ON
+					(e.building_id = b.id AND e.exception_date = $2::date)
+				WHERE
+					b.id = $1`, buildingTable, buildingHoursTable, buildingExceptionTable)
+
+	if err := s.db.Get(&inp, query, buildingId, date, weekday); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("repository.GetDayHours: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("repository.GetDayHours: %w", err)
+	}
+
+	return &inp, nil
+}
+
+func getUpcomingOrders(q sqlx.Queryer, buildingId int, now int64) ([]*domain.ScheduleConflict, error) {
+	inp := make([]*domain.ScheduleConflict, 0)
+
+	query := fmt.Sprintf(
+		`SELECT
+					o.id AS order_id,
+					o.pitch_id,
+					extract(epoch from o.order_date) AS order_date,
+					extract(epoch from o.start_order_date) AS start_order_date,
+					extract(epoch from o.end_order_date) AS end_order_date,
+					o.phone_number,
+					b.building_name
+				FROM
+					%s o
+				JOIN
+					%s p
+				ON
+					o.pitch_id = p.id
+				JOIN
+					%s b
+				ON
+					p.building_id = b.id
+				WHERE
+					b.id = $1 AND o.status <> $2 AND o.end_order_date >= to_timestamp($3)
+				ORDER BY
+					o.start_order_date, o.id`, orderTable, pitchTable, buildingTable)
+
+	if err := sqlx.Select(q, &inp, query, buildingId, domain.OrderCancelled, now); err != nil {
+		return nil, fmt.Errorf("repository.GetUpcomingOrders: %w", err)
+	}
+
+	return inp, nil
+}
+
+func getTimezone(q sqlx.Queryer, buildingId int) (string, error) {
+	var timezone string
+
+	query := fmt.Sprintf("SELECT timezone FROM %s WHERE id = $1", buildingTable)
+
+	if err := sqlx.Get(q, &timezone, query, buildingId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("repository.GetTimezone: %w", domain.ErrNotFound)
+		}
+		return "", fmt.Errorf("repository.GetTimezone: %w", err)
+	}
+
+	return timezone, nil
+}
+
+func (s *ScheduleRepos) GetBookingRules(buildingId int) (*domain.BookingRules, error) {
+	var inp domain.BookingRules
+
+	query := fmt.Sprintf("SELECT slot_length, min_duration, max_duration FROM %s WHERE id = $1", buildingTable)
+
+	if err := s.db.Get(&inp, query, buildingId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("repository.GetBookingRules: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("repository.GetBookingRules: %w", err)
+	}
+
+	return &inp, nil
+}
+
+// GetPitchBookingRules returns the rules of the pitch with the ones it does not set taken from the building.
+func (s *ScheduleRepos) GetPitchBookingRules(pitchId int) (*domain.PitchBookingRules, error) {
+	var inp domain.PitchBookingRules
+
+	query := fmt.Sprintf(
+		`SELECT
+					p.building_id,
+					coalesce(p.slot_length, b.slot_length) AS slot_length,
+					coalesce(p.min_duration, b.min_duration) AS min_duration,
+					coalesce(p.max_duration, b.max_duration) AS max_duration
+				FROM
+					%s p
+				JOIN
+					%s b
+				ON
+					p.building_id = b.id
+				WHERE
+					p.id = $1`, pitchTable, buildingTable)
+
+	if err := s.db.Get(&inp, query, pitchId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("repository.GetPitchBookingRules: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("repository.GetPitchBookingRules: %w", err)
+	}
+
+	return &inp, nil
+}
+
+func (s *ScheduleRepos) SetBookingRules(buildingId int, rules domain.BookingRules) error {
+	query := fmt.Sprintf("UPDATE %s SET slot_length = $1, min_duration = $2, max_duration = $3 WHERE id = $4", buildingTable)
+
+	result, err := s.db.Exec(query, rules.SlotLength, rules.MinDuration, rules.MaxDuration, buildingId)
+	if err != nil {
+		return fmt.Errorf("repository.SetBookingRules: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository.SetBookingRules: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("repository.SetBookingRules: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (s *ScheduleRepos) SetPitchBookingRules(pitchId int, rules domain.PitchBookingRulesInput) error {
+	query := fmt.Sprintf("UPDATE %s SET slot_length = $1, min_duration = $2, max_duration = $3 WHERE id = $4", pitchTable)
+
+	result, err := s.db.Exec(query, rules.SlotLength, rules.MinDuration, rules.MaxDuration, pitchId)
+	if err != nil {
+		return fmt.Errorf("repository.SetPitchBookingRules: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository.SetPitchBookingRules: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("repository.SetPitchBookingRules: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// SetWeek rewrites the hours of the days and cancels the orders resolve picks in one transaction.
+func (s *ScheduleRepos) SetWeek(buildingId int, days []domain.BuildingHours, resolve ScheduleResolver) error {
+	tx := s.db.MustBegin()
+
+	if err := lockBuilding(tx, buildingId); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.SetWeek: %w", txErr)
+		}
+		return fmt.Errorf("repository.SetWeek: %w", err)
+	}
+
+	for _, day := range days {
+		if err := setBuildingHours(tx, buildingId, day); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return fmt.Errorf("repository.SetWeek: %w", txErr)
+			}
+			return fmt.Errorf("repository.SetWeek: %w", err)
+		}
+	}
+
+	if err := resolveOrders(tx, resolve); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.SetWeek: %w", txErr)
+		}
+		return fmt.Errorf("repository.SetWeek: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// SetException adds the exception or replaces the one on the same date, the orders resolve
+// picks are cancelled in the same transaction.
+func (s *ScheduleRepos) SetException(buildingId int, exception domain.ScheduleException, resolve ScheduleResolver) (int, error) {
+	var id int
+
+	tx := s.db.MustBegin()
+
+	if err := lockBuilding(tx, buildingId); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return 0, fmt.Errorf("repository.SetException: %w", txErr)
+		}
+		return 0, fmt.Errorf("repository.SetException: %w", err)
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO
+					%s
+				(building_id, exception_date, is_closed, open_time, close_time, reason)
+					VALUES
+				($1, $2::date, $3, $4::time, $5::time, $6)
+				ON CONFLICT (building_id, exception_date) DO UPDATE SET
+					is_closed = excluded.is_closed, open_time = excluded.open_time,
+					close_time = excluded.close_time, reason = excluded.reason
+				RETURNING id`, buildingExceptionTable)
+
+	err := tx.QueryRowx(query, buildingId, exception.Date, exception.IsClosed, exception.OpenTime, exception.CloseTime, exception.Reason).Scan(&id)
+
+	if err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return 0, fmt.Errorf("repository.SetException: %w", txErr)
+		}
+		return 0, fmt.Errorf("repository.SetException: %w", err)
+	}
+
+	if err = resolveOrders(tx, resolve); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return 0, fmt.Errorf("repository.SetException: %w", txErr)
+		}
+		return 0, fmt.Errorf("repository.SetException: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("repository.SetException: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *ScheduleRepos) DeleteException(buildingId, id int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND building_id = $2", buildingExceptionTable)
+
+	result, err := s.db.Exec(query, id, buildingId)
+	if err != nil {
+		return fmt.Errorf("repository.DeleteException: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository.DeleteException: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("repository.DeleteException: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func setBuildingHours(tx *sqlx.Tx, buildingId int, day domain.BuildingHours) error {
+	query := fmt.Sprintf(
+		`INSERT INTO
+					%s
+				(building_id, weekday, is_closed, open_time, close_time)
+					VALUES
+				($1, $2, $3, $4::time, $5::time)
+				ON CONFLICT (building_id, weekday) DO UPDATE SET
+					is_closed = excluded.is_closed, open_time = excluded.open_time, close_time = excluded.close_time`, buildingHoursTable)
+
+	_, err := tx.Exec(query, buildingId, day.Weekday, day.IsClosed, day.OpenTime, day.CloseTime)
+
+	return err
+}
+
+// lockBuilding keeps orders from being placed in the building until the transaction ends,
+// so the upcoming orders checked against new hours are all there are.
+func lockBuilding(tx *sqlx.Tx, buildingId int) error {
+	var id int
+
+	query := fmt.Sprintf("SELECT id FROM %s WHERE id = $1 FOR UPDATE", buildingTable)
+
+	if err := tx.Get(&id, query, buildingId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// resolveOrders lets resolve check the upcoming orders against the schedule as the transaction
+// changed it and cancels the ones it returns.
+func resolveOrders(tx *sqlx.Tx, resolve ScheduleResolver) error {
+	cancel, err := resolve(scheduleTx{tx: tx})
+	if err != nil {
+		return err
+	}
+
+	return cancelOrders(tx, cancel)
+}
+
+func cancelOrders(tx *sqlx.Tx, ids []int) error {
+	query := fmt.Sprintf("UPDATE %s SET status = $1 WHERE id = $2", orderTable)
+
+	for _, id := range ids {
+		if _, err := tx.Exec(query, domain.OrderCancelled, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
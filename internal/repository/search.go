@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"carWash/internal/domain"
+	"context"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// buildingSearchVector has to stay the same as the expression of buildings_search_idx.
+const buildingSearchVector = `(
+	setweight(to_tsvector('russian', b.building_name), 'A') || setweight(to_tsvector('english', b.building_name), 'A') ||
+	setweight(to_tsvector('russian', b.address), 'B') || setweight(to_tsvector('english', b.address), 'B') ||
+	setweight(to_tsvector('russian', b.description), 'C') || setweight(to_tsvector('english', b.description), 'C'))`
+
+// buildingSearchQuery matches the text from the $n argument by stems in both languages,
+// names and addresses with a typo are still found by trigrams.
+func buildingSearchQuery(n int) string {
+	return fmt.Sprintf(
+		`(%s @@ (websearch_to_tsquery('russian', $%d) || websearch_to_tsquery('english', $%d))
+			OR $%d::text <%% b.building_name OR $%d::text <%% b.address)`,
+		buildingSearchVector, n, n, n, n)
+}
+
+func buildingSearchRank(n int) string {
+	return fmt.Sprintf(
+		`(ts_rank(%s, websearch_to_tsquery('russian', $%d) || websearch_to_tsquery('english', $%d))
+			+ greatest(word_similarity($%d::text, b.building_name), word_similarity($%d::text, b.address)))`,
+		buildingSearchVector, n, n, n, n)
+}
+
+// Suggest finds buildings by the beginning of the words the user is typing.
+func (b *BuildingRepos) Suggest(ctx *fiber.Ctx, q string, limit int) ([]*domain.BuildingSuggestion, error) {
+	_, cancel := context.WithTimeout(ctx.Context(), 4*time.Second)
+
+	defer cancel()
+
+	inp := make([]*domain.BuildingSuggestion, 0)
+
+	prefix := prefixTsQuery(q)
+
+	if prefix == "" {
+		return inp, nil
+	}
+
+	query := fmt.Sprintf(
+		`SELECT
+					b.id, b.building_name, b.address
+				FROM
+					%s b
+				WHERE
+					%s @@ (to_tsquery('russian', $1) || to_tsquery('english', $1))
+					OR $2::text <%% b.building_name OR $2::text <%% b.address
+				ORDER BY
+					ts_rank(%s, to_tsquery('russian', $1) || to_tsquery('english', $1))
+						+ greatest(word_similarity($2::text, b.building_name), word_similarity($2::text, b.address)) DESC,
+					b.id ASC
+				LIMIT $3`, buildingTable, buildingSearchVector, buildingSearchVector)
+
+	if err := b.db.Select(&inp, query, prefix, q, limit); err != nil {
+		return nil, fmt.Errorf("repository.Suggest: %w", err)
+	}
+
+	return inp, nil
+}
+
+// prefixTsQuery turns the typed text into "word:* & word:*", everything but letters
+// and digits is dropped so the text can not break the tsquery syntax.
+func prefixTsQuery(q string) string {
+	words := strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i := range words {
+		words[i] = words[i] + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"carWash/internal/domain"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+func (u *UserAuthRepos) GetTwoFactor(userId int) (*domain.TwoFactor, error) {
+	var inp domain.TwoFactor
+
+	query := fmt.Sprintf("SELECT user_id, secret, enabled FROM %s WHERE user_id = $1", twoFactorTable)
+
+	err := u.db.Get(&inp, query, userId)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("repository.GetTwoFactor: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("repository.GetTwoFactor: %w", err)
+	}
+
+	return &inp, nil
+}
+
+// SetTwoFactorSecret starts a new enrollment, an enabled secret is never overwritten.
+func (u *UserAuthRepos) SetTwoFactorSecret(userId int, secret string) error {
+	query := fmt.Sprintf(
+		`INSERT INTO
+					%s
+				(user_id, secret)
+					VALUES
+				($1,$2)
+				ON CONFLICT (user_id) DO UPDATE SET
+					secret = excluded.secret, created_at = now()
+				WHERE
+					%s.enabled = false`, twoFactorTable, twoFactorTable)
+
+	result, err := u.db.Exec(query, userId, secret)
+
+	if err != nil {
+		return fmt.Errorf("repository.SetTwoFactorSecret: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository.SetTwoFactorSecret: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("repository.SetTwoFactorSecret: %w", domain.ErrTwoFactorAlreadyEnabled)
+	}
+
+	return nil
+}
+
+func (u *UserAuthRepos) EnableTwoFactor(userId int, codeHashes []string) error {
+	tx := u.db.MustBegin()
+
+	query := fmt.Sprintf("UPDATE %s SET enabled = true, enabled_at = now() WHERE user_id = $1 AND enabled = false", twoFactorTable)
+
+	result, err := tx.Exec(query, userId)
+
+	if err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.EnableTwoFactor: %w", txErr)
+		}
+		return fmt.Errorf("repository.EnableTwoFactor: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+
+	if err != nil || affected == 0 {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.EnableTwoFactor: %w", txErr)
+		}
+		return fmt.Errorf("repository.EnableTwoFactor: %w", domain.ErrTwoFactorAlreadyEnabled)
+	}
+
+	if err = replaceRecoveryCodes(tx, userId, codeHashes); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.EnableTwoFactor: %w", txErr)
+		}
+		return fmt.Errorf("repository.EnableTwoFactor: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (u *UserAuthRepos) SetRecoveryCodes(userId int, codeHashes []string) error {
+	tx := u.db.MustBegin()
+
+	if err := replaceRecoveryCodes(tx, userId, codeHashes); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.SetRecoveryCodes: %w", txErr)
+		}
+		return fmt.Errorf("repository.SetRecoveryCodes: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (u *UserAuthRepos) GetRecoveryCodes(userId int) ([]*domain.RecoveryCode, error) {
+	inp := make([]*domain.RecoveryCode, 0)
+
+	query := fmt.Sprintf("SELECT id, code_hash FROM %s WHERE user_id = $1 AND used_at IS NULL", recoveryCodeTable)
+
+	err := u.db.Select(&inp, query, userId)
+
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetRecoveryCodes: %w", err)
+	}
+
+	return inp, nil
+}
+
+// UseRecoveryCode marks the code as used, a code that is already used is reported as invalid.
+func (u *UserAuthRepos) UseRecoveryCode(id int) error {
+	query := fmt.Sprintf("UPDATE %s SET used_at = now() WHERE id = $1 AND used_at IS NULL", recoveryCodeTable)
+
+	result, err := u.db.Exec(query, id)
+
+	if err != nil {
+		return fmt.Errorf("repository.UseRecoveryCode: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository.UseRecoveryCode: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("repository.UseRecoveryCode: %w", domain.ErrInvalidTwoFactorCode)
+	}
+
+	return nil
+}
+
+func (u *UserAuthRepos) DeleteTwoFactor(userId int) error {
+	tx := u.db.MustBegin()
+
+	queryCodes := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1", recoveryCodeTable)
+
+	if _, err := tx.Exec(queryCodes, userId); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.DeleteTwoFactor: %w", txErr)
+		}
+		return fmt.Errorf("repository.DeleteTwoFactor: %w", err)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1", twoFactorTable)
+
+	if _, err := tx.Exec(query, userId); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("repository.DeleteTwoFactor: %w", txErr)
+		}
+		return fmt.Errorf("repository.DeleteTwoFactor: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func replaceRecoveryCodes(tx *sqlx.Tx, userId int, codeHashes []string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1", recoveryCodeTable)
+
+	if _, err := tx.Exec(query, userId); err != nil {
+		return err
+	}
+
+	queryInsert := fmt.Sprintf("INSERT INTO %s(user_id, code_hash) VALUES($1,$2)", recoveryCodeTable)
+
+	for _, hash := range codeHashes {
+		if _, err := tx.Exec(queryInsert, userId, hash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
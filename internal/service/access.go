@@ -0,0 +1,120 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"carWash/internal/repository"
+	"errors"
+	"fmt"
+)
+
+type AccessService struct {
+	repos repository.Access
+	users repository.UserAuth
+}
+
+func NewAccessService(repos repository.Access, users repository.UserAuth) *AccessService {
+	return &AccessService{repos: repos, users: users}
+}
+
+// HasPermission reports whether the user may perform the action on the building,
+// buildingId 0 asks for a global permission which only admins hold.
+func (a *AccessService) HasPermission(userType string, userId, buildingId int, permission string) (bool, error) {
+	if userType == "admin" {
+		// admin rights are only granted to accounts protected by a second factor
+		twoFactor, err := a.users.GetTwoFactor(userId)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return false, fmt.Errorf("service.HasPermission: %w", err)
+		}
+
+		if err != nil || !twoFactor.Enabled {
+			return false, fmt.Errorf("service.HasPermission: %w", domain.ErrTwoFactorRequired)
+		}
+		return true, nil
+	}
+
+	if buildingId == 0 || !isBuildingPermission(permission) {
+		return false, nil
+	}
+
+	ownerId, err := a.repos.GetBuildingOwner(buildingId)
+	if err != nil {
+		return false, fmt.Errorf("service.HasPermission: %w", err)
+	}
+
+	// an owner who has been demoted or rejected since keeps only the rights granted as staff
+	if ownerId == userId {
+		approved, err := a.repos.IsApprovedManager(userId)
+		if err != nil {
+			return false, fmt.Errorf("service.HasPermission: %w", err)
+		}
+
+		if approved {
+			return true, nil
+		}
+	}
+
+	permissions, err := a.repos.GetStaffPermissions(userId, buildingId)
+	if err != nil {
+		return false, fmt.Errorf("service.HasPermission: %w", err)
+	}
+
+	for _, p := range permissions {
+		if p == permission {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (a *AccessService) GetPitchBuilding(pitchId int) (int, error) {
+	return a.repos.GetPitchBuilding(pitchId)
+}
+
+func (a *AccessService) GetRoles() ([]*domain.Role, error) {
+	return a.repos.GetRoles()
+}
+
+func (a *AccessService) GetStaff(buildingId int) ([]*domain.Staff, error) {
+	return a.repos.GetStaff(buildingId)
+}
+
+func (a *AccessService) AddStaff(buildingId int, input domain.StaffInput) (int, error) {
+	user, err := a.users.VerifyViaPhoneNumber(input.PhoneNumber)
+	if err != nil {
+		return 0, fmt.Errorf("service.AddStaff: %w", err)
+	}
+
+	ownerId, err := a.repos.GetBuildingOwner(buildingId)
+	if err != nil {
+		return 0, fmt.Errorf("service.AddStaff: %w", err)
+	}
+
+	if ownerId == user.Id {
+		return 0, fmt.Errorf("service.AddStaff: %w", domain.ErrStaffIsOwner)
+	}
+
+	exists, err := a.repos.RoleExists(input.RoleId)
+	if err != nil {
+		return 0, fmt.Errorf("service.AddStaff: %w", err)
+	}
+
+	if !exists {
+		return 0, fmt.Errorf("service.AddStaff: %w", domain.ErrNotFound)
+	}
+
+	return a.repos.SetStaff(buildingId, user.Id, input.RoleId)
+}
+
+func (a *AccessService) RemoveStaff(buildingId, userId int) error {
+	return a.repos.RemoveStaff(buildingId, userId)
+}
+
+func isBuildingPermission(permission string) bool {
+	for _, p := range domain.BuildingPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"carWash/internal/repository"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"strings"
+	"time"
+)
+
+// DeleteAccount asks for the password again, tokens of the deleted account stop working right away.
+func (u *UserAuthService) DeleteAccount(userId int, password string) error {
+	hashedPassword, err := u.repos.GetPassword(userId)
+	if err != nil {
+		return fmt.Errorf("service.DeleteAccount: %w", err)
+	}
+
+	ok, err := u.hashes.Verify(password, hashedPassword)
+	if err != nil || !ok {
+		return fmt.Errorf("service.DeleteAccount: %w", domain.ErrWrongPassword)
+	}
+
+	sessions, err := u.repos.GetSessions(userId)
+	if err != nil {
+		return fmt.Errorf("service.DeleteAccount: %w", err)
+	}
+
+	if err = u.repos.DeleteUser(userId); err != nil {
+		return fmt.Errorf("service.DeleteAccount: %w", err)
+	}
+
+	ids := make([]int, 0, len(sessions))
+
+	for _, session := range sessions {
+		ids = append(ids, session.Id)
+	}
+
+	if err = u.denySessions(ids...); err != nil {
+		return fmt.Errorf("service.DeleteAccount: %w", err)
+	}
+
+	return nil
+}
+
+type AccountService struct {
+	users      repository.UserAuth
+	orders     repository.Order
+	cards      repository.Card
+	favourites repository.Favourite
+	admin      repository.Admin
+}
+
+func NewAccountService(users repository.UserAuth, orders repository.Order, cards repository.Card,
+	favourites repository.Favourite, admin repository.Admin) *AccountService {
+	return &AccountService{users: users, orders: orders, cards: cards, favourites: favourites, admin: admin}
+}
+
+// Export collects the user's data, an empty pagination returns every row.
+func (a *AccountService) Export(ctx *fiber.Ctx, userId int) (*domain.AccountExport, error) {
+	var all domain.Pagination
+
+	profile, err := a.users.GetUser(userId)
+	if err != nil {
+		return nil, fmt.Errorf("service.Export: %w", err)
+	}
+
+	orders, err := a.orders.GetAll(ctx, all, domain.UserInfo{Id: userId, Type: "user"}, domain.FilterForOrder{})
+	if err != nil {
+		return nil, fmt.Errorf("service.Export: %w", err)
+	}
+
+	cards, err := a.cards.GetAll(ctx, all, userId)
+	if err != nil {
+		return nil, fmt.Errorf("service.Export: %w", err)
+	}
+
+	comments, err := a.admin.GetUserComments(ctx, all, userId)
+	if err != nil {
+		return nil, fmt.Errorf("service.Export: %w", err)
+	}
+
+	favourites, err := a.favourites.GetAll(ctx, all, userId)
+	if err != nil {
+		return nil, fmt.Errorf("service.Export: %w", err)
+	}
+
+	feedbacks, err := a.admin.GetUserFeedbacks(ctx, all, userId)
+	if err != nil {
+		return nil, fmt.Errorf("service.Export: %w", err)
+	}
+
+	masked := make([]*domain.MaskedCard, 0)
+
+	if list, ok := cards.Data.([]*domain.Card); ok {
+		for _, card := range list {
+			masked = append(masked, &domain.MaskedCard{Id: card.Id, FullName: card.FullName, Number: maskCardNumber(card.FullNumber)})
+		}
+	}
+
+	return &domain.AccountExport{
+		Profile:    profile,
+		Orders:     orders.Data,
+		Cards:      masked,
+		Comments:   comments.Data,
+		Favourites: favourites.Data,
+		Feedbacks:  feedbacks.Data,
+		ExportedAt: time.Now().Unix(),
+	}, nil
+}
+
+// maskCardNumber keeps only the last four digits.
+func maskCardNumber(number string) string {
+	digits := strings.ReplaceAll(number, " ", "")
+
+	if len(digits) <= 4 {
+		return strings.Repeat("*", len(digits))
+	}
+
+	return strings.Repeat("*", len(digits)-4) + digits[len(digits)-4:]
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"carWash/internal/repository"
+	"carWash/pkg/logger"
+	"carWash/pkg/sms"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AdminService struct {
+	repos        repository.Admin
+	orders       repository.Order
+	userAuth     UserAuth
+	twoFactor    TwoFactor
+	smsSender    sms.Sender
+	smsTemplates *sms.Templates
+}
+
+func NewAdminService(repos repository.Admin, orders repository.Order, userAuth UserAuth, twoFactor TwoFactor, smsSender sms.Sender, smsTemplates *sms.Templates) *AdminService {
+	return &AdminService{repos: repos, orders: orders, userAuth: userAuth, twoFactor: twoFactor, smsSender: smsSender, smsTemplates: smsTemplates}
+}
+
+func (a *AdminService) GetAllUsers(ctx *fiber.Ctx, page domain.Pagination, filter domain.FilterForUser) (*domain.GetAllResponses, error) {
+	return a.repos.GetAllUsers(ctx, page, filter)
+}
+
+func (a *AdminService) GetUserById(ctx *fiber.Ctx, id int) (*domain.AdminUser, error) {
+	return a.repos.GetUserById(ctx, id)
+}
+
+// SetRole changes the user type, the role is baked into issued tokens so the user's sessions are ended.
+func (a *AdminService) SetRole(ctx *fiber.Ctx, adminId, id int, userType string) error {
+	if adminId == id {
+		return fmt.Errorf("service.SetRole: %w", domain.ErrSelfModification)
+	}
+
+	if err := a.repos.SetRole(ctx, adminId, id, userType); err != nil {
+		return fmt.Errorf("service.SetRole: %w", err)
+	}
+
+	if err := a.userAuth.RevokeAllSessions(id); err != nil {
+		return fmt.Errorf("service.SetRole: %w", err)
+	}
+
+	return nil
+}
+
+func (a *AdminService) Ban(ctx *fiber.Ctx, adminId, id int, reason string) error {
+	if adminId == id {
+		return fmt.Errorf("service.Ban: %w", domain.ErrSelfModification)
+	}
+
+	if err := a.repos.SetBanned(ctx, id, true, reason); err != nil {
+		return fmt.Errorf("service.Ban: %w", err)
+	}
+
+	if err := a.userAuth.RevokeAllSessions(id); err != nil {
+		return fmt.Errorf("service.Ban: %w", err)
+	}
+
+	return nil
+}
+
+// ResetTwoFactor removes the user's 2FA so it can be enrolled again, an admin can not reset their own.
+func (a *AdminService) ResetTwoFactor(ctx *fiber.Ctx, adminId, userId int) error {
+	if adminId == userId {
+		return fmt.Errorf("service.ResetTwoFactor: %w", domain.ErrSelfModification)
+	}
+
+	if _, err := a.repos.GetUserById(ctx, userId); err != nil {
+		return fmt.Errorf("service.ResetTwoFactor: %w", err)
+	}
+
+	if err := a.twoFactor.ResetTwoFactor(userId); err != nil {
+		return fmt.Errorf("service.ResetTwoFactor: %w", err)
+	}
+
+	return nil
+}
+
+func (a *AdminService) Unban(ctx *fiber.Ctx, id int) error {
+	if err := a.repos.SetBanned(ctx, id, false, ""); err != nil {
+		return fmt.Errorf("service.Unban: %w", err)
+	}
+
+	return nil
+}
+
+func (a *AdminService) GetUserOrders(ctx *fiber.Ctx, page domain.Pagination, userId int) (*domain.GetAllResponses, error) {
+	return a.orders.GetAll(ctx, page, domain.UserInfo{Id: userId, Type: "user"}, domain.FilterForOrder{})
+}
+
+func (a *AdminService) GetUserComments(ctx *fiber.Ctx, page domain.Pagination, userId int) (*domain.GetAllResponses, error) {
+	return a.repos.GetUserComments(ctx, page, userId)
+}
+
+func (a *AdminService) GetUserFeedbacks(ctx *fiber.Ctx, page domain.Pagination, userId int) (*domain.GetAllResponses, error) {
+	return a.repos.GetUserFeedbacks(ctx, page, userId)
+}
+
+func (a *AdminService) GetAllManagers(ctx *fiber.Ctx, page domain.Pagination, filter domain.FilterForManager) (*domain.GetAllResponses, error) {
+	return a.repos.GetAllManagers(ctx, page, filter)
+}
+
+func (a *AdminService) ApproveManager(ctx *fiber.Ctx, adminId, userId int) error {
+	profile, err := a.reviewManager(ctx, adminId, userId, domain.ManagerApproved, "")
+	if err != nil {
+		return fmt.Errorf("service.ApproveManager: %w", err)
+	}
+
+	message, err := a.smsTemplates.ManagerApproved(profile.Language, profile.Name)
+	if err != nil {
+		return fmt.Errorf("service.ApproveManager: %w", err)
+	}
+
+	a.notify(profile.PhoneNumber, message)
+
+	return nil
+}
+
+func (a *AdminService) RejectManager(ctx *fiber.Ctx, adminId, userId int, reason string) error {
+	profile, err := a.reviewManager(ctx, adminId, userId, domain.ManagerRejected, reason)
+	if err != nil {
+		return fmt.Errorf("service.RejectManager: %w", err)
+	}
+
+	message, err := a.smsTemplates.ManagerRejected(profile.Language, profile.Name, reason)
+	if err != nil {
+		return fmt.Errorf("service.RejectManager: %w", err)
+	}
+
+	a.notify(profile.PhoneNumber, message)
+
+	return nil
+}
+
+func (a *AdminService) reviewManager(ctx *fiber.Ctx, adminId, userId int, status, reason string) (*domain.ManagerProfile, error) {
+	profile, err := a.repos.GetManagerProfile(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("service.reviewManager: %w", err)
+	}
+
+	if profile.Status == status {
+		return nil, fmt.Errorf("service.reviewManager: %w", domain.ErrManagerAlreadyReviewed)
+	}
+
+	if err = a.repos.SetManagerStatus(ctx, userId, adminId, status, reason); err != nil {
+		return nil, fmt.Errorf("service.reviewManager: %w", err)
+	}
+
+	return profile, nil
+}
+
+// notify does not fail the review, the decision is already stored and visible to the manager.
+func (a *AdminService) notify(phone, message string) {
+	if err := a.smsSender.Send(phone, message); err != nil {
+		logger.Error(fmt.Errorf("service.notify: %w", err))
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"carWash/internal/repository"
+	"carWash/pkg/logger"
+	"context"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+type AuditService struct {
+	repos     repository.Audit
+	retention time.Duration
+}
+
+func NewAuditService(repos repository.Audit, retention time.Duration) *AuditService {
+	return &AuditService{repos: repos, retention: retention}
+}
+
+// Record never fails the action being audited, a lost entry is only logged.
+func (a *AuditService) Record(entry domain.AuditEntry) {
+	if entry.Outcome == "" {
+		entry.Outcome = domain.AuditSuccess
+	}
+
+	if err := a.repos.Create(entry); err != nil {
+		logger.Errorf("service.Record: %s: %s", entry.Action, err.Error())
+	}
+}
+
+func (a *AuditService) GetAll(ctx *fiber.Ctx, page domain.Pagination, filter domain.FilterForAudit) (*domain.GetAllResponses, error) {
+	return a.repos.GetAll(ctx, page, filter)
+}
+
+// Purge drops the entries older than the retention period, zero retention keeps everything.
+func (a *AuditService) Purge() error {
+	if a.retention <= 0 {
+		return nil
+	}
+
+	deleted, err := a.repos.DeleteOlderThan(time.Now().Add(-a.retention))
+	if err != nil {
+		return fmt.Errorf("service.Purge: %w", err)
+	}
+
+	if deleted > 0 {
+		logger.Infof("audit log: %d entries past retention removed", deleted)
+	}
+
+	return nil
+}
+
+func (a *AuditService) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.Purge(); err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+}
@@ -6,10 +6,14 @@ import (
 	"carWash/pkg/auth"
 	"carWash/pkg/hash"
 	"carWash/pkg/phone"
+	"carWash/pkg/sms"
+	"carWash/pkg/totp"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -22,6 +26,13 @@ type UserAuthService struct {
 	tokenManager    auth.TokenManager
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
+	guestTokenTTL   time.Duration
+	smsSender       sms.Sender
+	smsTemplates    *sms.Templates
+	otp             OTPPolicy
+	emails          *EmailService
+	totp            *totp.Generator
+	twoFactor       TwoFactorPolicy
 }
 
 func NewUserAuthService(
@@ -32,7 +43,14 @@ func NewUserAuthService(
 	ctx context.Context,
 	tokenManager auth.TokenManager,
 	accessTokenTTL time.Duration,
-	refreshTokenTTL time.Duration) *UserAuthService {
+	refreshTokenTTL time.Duration,
+	guestTokenTTL time.Duration,
+	smsSender sms.Sender,
+	smsTemplates *sms.Templates,
+	otp OTPPolicy,
+	emails *EmailService,
+	totp *totp.Generator,
+	twoFactor TwoFactorPolicy) *UserAuthService {
 	return &UserAuthService{
 		repos:           repos,
 		hashes:          hashes,
@@ -42,6 +60,13 @@ func NewUserAuthService(
 		tokenManager:    tokenManager,
 		accessTokenTTL:  accessTokenTTL,
 		refreshTokenTTL: refreshTokenTTL,
+		guestTokenTTL:   guestTokenTTL,
+		smsSender:       smsSender,
+		smsTemplates:    smsTemplates,
+		otp:             otp,
+		emails:          emails,
+		totp:            totp,
+		twoFactor:       twoFactor,
 	}
 }
 
@@ -71,32 +96,41 @@ func (u *UserAuthService) UserSignUp(input SignUpInput) (string, error) {
 		UserType:    input.UserType,
 	}
 
-	code, err := u.SetSecretCode(input.PhoneNumber)
+	code, err := u.SetSecretCode(input.PhoneNumber, input.Language)
 	if err != nil {
 		return "", fmt.Errorf("service.UserSignUp: %w", err)
 	}
 
+	var userId int
+
 	list, err := u.repos.VerifyExistenceUser(input.PhoneNumber, false)
 
 	fmt.Println(err)
 	if err != nil {
-		_, err = u.repos.CreateUser(user)
+		userId, err = u.repos.CreateUser(user)
 		if err != nil {
 			return "", fmt.Errorf("service.UserSignUp: %w", err)
 		}
 
 	} else {
+		userId = list.Id
 		err = u.repos.UpdateUser(user, list.Id)
 		if err != nil {
 			return "", fmt.Errorf("service.UserSignUp: %w", err)
 		}
 	}
 
+	if input.Business != nil {
+		if err = u.repos.SetManagerProfile(userId, *input.Business, input.Language); err != nil {
+			return "", fmt.Errorf("service.UserSignUp: %w", err)
+		}
+	}
+
 	return code, nil
 }
 
-func (u *UserAuthService) Verify(input domain.VerifyUserInput) error {
-	err := u.GetSecretCode(input.PhoneCode, input.Phone)
+func (u *UserAuthService) Verify(input domain.VerifyUserInput, ip string) error {
+	err := u.GetSecretCode(input.PhoneCode, input.Phone, ip)
 	if err != nil {
 		return fmt.Errorf("service.Verify: %w", err)
 	}
@@ -108,35 +142,62 @@ func (u *UserAuthService) Verify(input domain.VerifyUserInput) error {
 	return nil
 }
 
-func (u *UserAuthService) UserSignIn(user domain.User) (*Tokens, error) {
+func (u *UserAuthService) UserSignIn(user domain.User, device domain.Device) (*Tokens, error) {
 
-	hashedPassword, err := u.hashes.Hash(user.Password)
+	input, err := u.repos.SignIn(user.PhoneNumber)
 
 	if err != nil {
 		return nil, fmt.Errorf("service.UserSignIn: %w", err)
 	}
 
-	input, err := u.repos.SignIn(user.PhoneNumber, hashedPassword)
+	ok, err := u.hashes.Verify(user.Password, input.Password)
 
 	if err != nil {
 		return nil, fmt.Errorf("service.UserSignIn: %w", err)
 	}
 
-	return u.createSession(input.Id, input.UserType)
+	if !ok {
+		return nil, fmt.Errorf("service.UserSignIn: %w", domain.ErrUserDoesNotExist)
+	}
+
+	if u.hashes.NeedsRehash(input.Password) {
+		if err = u.rehashPassword(input.Id, user.Password); err != nil {
+			return nil, fmt.Errorf("service.UserSignIn: %w", err)
+		}
+	}
+
+	enabled, err := u.IsTwoFactorEnabled(input.Id)
+	if err != nil {
+		return nil, fmt.Errorf("service.UserSignIn: %w", err)
+	}
+
+	if enabled {
+		challenge, err := u.newTwoFactorChallenge(input.Id, input.UserType, device)
+		if err != nil {
+			return nil, fmt.Errorf("service.UserSignIn: %w", err)
+		}
+		return &Tokens{UserId: input.Id, TwoFactorChallenge: challenge}, nil
+	}
+
+	return u.createSession(input.Id, input.UserType, device)
 }
 
 func (u *UserAuthService) SetPassword(id int, input domain.SetPasswordInput) error {
 
-	hashedOldPassword, err := u.hashes.Hash(input.CurrentPassword)
+	hashedOldPassword, err := u.repos.GetPassword(id)
 
 	if err != nil {
 		return fmt.Errorf("service.SetPassword: %w", err)
 	}
 
-	err = u.repos.VerifyViaPassword(id, hashedOldPassword)
+	ok, err := u.hashes.Verify(input.CurrentPassword, hashedOldPassword)
 
 	if err != nil {
-		return fmt.Errorf("servcie.SetPassword: %w", err)
+		return fmt.Errorf("service.SetPassword: %w", err)
+	}
+
+	if !ok {
+		return fmt.Errorf("servcie.SetPassword: %w", domain.ErrInvalidPassword)
 	}
 
 	if input.NewPassword != input.ConfirmNewPassword {
@@ -149,10 +210,22 @@ func (u *UserAuthService) SetPassword(id int, input domain.SetPasswordInput) err
 		return fmt.Errorf("service.SetPassword: %w", err)
 	}
 
-	return u.repos.SetPassword(id, hashedOldPassword, hashedNewPassword)
+	return u.repos.SetPassword(id, hashedNewPassword)
 }
 
-func (u *UserAuthService) ResetPassword(phone string) (string, error) {
+// rehashPassword replaces a hash made with outdated parameters or the legacy SHA1 scheme,
+// the plain password is only known right after it was verified.
+func (u *UserAuthService) rehashPassword(id int, password string) error {
+	hashedPassword, err := u.hashes.Hash(password)
+
+	if err != nil {
+		return fmt.Errorf("service.rehashPassword: %w", err)
+	}
+
+	return u.repos.SetPassword(id, hashedPassword)
+}
+
+func (u *UserAuthService) ResetPassword(phone, language string) (string, error) {
 
 	_, err := u.repos.VerifyViaPhoneNumber(phone)
 
@@ -160,7 +233,7 @@ func (u *UserAuthService) ResetPassword(phone string) (string, error) {
 		return "", fmt.Errorf("service.ResetPassword: %w", err)
 	}
 
-	secret, err := u.SetSecretCode(phone)
+	secret, err := u.SetSecretCode(phone, language)
 
 	if err != nil {
 		return "", fmt.Errorf("service.ResetPassword: %w", err)
@@ -174,14 +247,14 @@ func (u *UserAuthService) ResetPassword(phone string) (string, error) {
 
 }
 
-func (u *UserAuthService) VerifyPhoneNumber(input domain.VerifyPhoneNumberInput) error {
-	err := u.GetSecretCode(input.SecretCode, input.PhoneNumber)
+func (u *UserAuthService) VerifyPhoneNumber(input domain.VerifyPhoneNumberInput, ip string) error {
+	err := u.GetSecretCode(input.SecretCode, input.PhoneNumber, ip)
 
 	if err != nil {
 		return fmt.Errorf("service.ResetPasswordConfirm: %w", err)
 	}
 
-	err = u.redis.Set(u.ctx, input.PhoneNumber, true, 2*time.Minute).Err()
+	err = u.redis.Set(u.ctx, resetPasswordKey(input.PhoneNumber), true, 2*time.Minute).Err()
 
 	if err != nil {
 		return fmt.Errorf("service.VerifyPhoneNumber: %w", err)
@@ -191,7 +264,11 @@ func (u *UserAuthService) VerifyPhoneNumber(input domain.VerifyPhoneNumberInput)
 }
 
 func (u *UserAuthService) ResetPasswordConfirm(input domain.ResetPasswordInput) error {
-	_, err := u.redis.Get(u.ctx, input.PhoneNumber).Result()
+	_, err := u.redis.Get(u.ctx, resetPasswordKey(input.PhoneNumber)).Result()
+
+	if err == redis.Nil {
+		return fmt.Errorf("service.ResetPasswordConfirm: %w", domain.ErrInvalidSecretCode)
+	}
 
 	if err != nil {
 		return fmt.Errorf("service.ResetPasswordConfirm: %w", err)
@@ -211,12 +288,16 @@ func (u *UserAuthService) ResetPasswordConfirm(input domain.ResetPasswordInput)
 		return fmt.Errorf("service.ResetPasswordConfirm: %w", err)
 	}
 
+	if err := u.redis.Del(u.ctx, resetPasswordKey(input.PhoneNumber)).Err(); err != nil {
+		return fmt.Errorf("service.ResetPasswordConfirm: %w", err)
+	}
+
 	return nil
 }
 
-func (u *UserAuthService) UpdatePhoneNumberVerify(inp domain.User) (string, error) {
+func (u *UserAuthService) UpdatePhoneNumberVerify(inp domain.User, language string) (string, error) {
 
-	secret, err := u.SetSecretCode(inp.PhoneNumber)
+	secret, err := u.SetSecretCode(inp.PhoneNumber, language)
 
 	if err != nil {
 		return "", fmt.Errorf("service.ResetPassword: %w", err)
@@ -224,8 +305,8 @@ func (u *UserAuthService) UpdatePhoneNumberVerify(inp domain.User) (string, erro
 	return secret, nil
 }
 
-func (u *UserAuthService) UpdatePhoneNumberConfirm(input domain.ResetPhoneNumberInput, id int) error {
-	err := u.GetSecretCode(input.SecretCode, input.PhoneNumber)
+func (u *UserAuthService) UpdatePhoneNumberConfirm(input domain.ResetPhoneNumberInput, id int, ip string) error {
+	err := u.GetSecretCode(input.SecretCode, input.PhoneNumber, ip)
 	if err != nil {
 		return fmt.Errorf("service.UpdatePhoneNumberConfirm: %w", err)
 	}
@@ -239,7 +320,11 @@ func (u *UserAuthService) UpdatePhoneNumberConfirm(input domain.ResetPhoneNumber
 
 }
 
-func (u *UserAuthService) SetSecretCode(phone string) (string, error) {
+func (u *UserAuthService) SetSecretCode(phone, language string) (string, error) {
+	if err := u.reserveOTPResend(phone); err != nil {
+		return "", fmt.Errorf("service.SetSecretCode: %w", err)
+	}
+
 	secret, err := u.otpPhone.GetRandNum()
 	if err != nil {
 		return "", fmt.Errorf("service.SetSecretCode: %w", err)
@@ -253,25 +338,65 @@ func (u *UserAuthService) SetSecretCode(phone string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("service.SetSecretCode: %w", err)
 	}
+
+	message, err := u.smsTemplates.SecretCode(language, secret)
+	if err != nil {
+		return "", fmt.Errorf("service.SetSecretCode: %w", err)
+	}
+
+	if err = u.smsSender.Send(phone, message); err != nil {
+		return "", fmt.Errorf("service.SetSecretCode: %w", err)
+	}
 	return secret, nil
 }
 
-func (u *UserAuthService) GetSecretCode(code, phone string) error {
-	hashSecret, err := u.hashes.Hash(code)
-
+func (u *UserAuthService) GetSecretCode(code, phone, ip string) error {
+	attempts, err := u.reserveOTPAttempt(phone, ip)
 	if err != nil {
 		return fmt.Errorf("service.GetSecretCode: %w", err)
 	}
 
 	val, err := u.redis.Get(u.ctx, phone).Result()
 
+	if err == redis.Nil {
+		return fmt.Errorf("service.GetSecretCode: %w", domain.ErrInvalidSecretCode)
+	}
+
 	if err != nil {
 		return fmt.Errorf("service.GetSecretCode: %w", err)
 	}
 
-	if val != hashSecret {
+	ok, err := u.hashes.Verify(code, val)
+
+	if err != nil {
+		return fmt.Errorf("service.GetSecretCode: %w", err)
+	}
+
+	if !ok {
+		// once the phone is locked out its code is dropped, a new one is needed after the lockout
+		if attempts >= int64(u.otp.MaxAttempts) {
+			if err = u.redis.Del(u.ctx, phone).Err(); err != nil {
+				return fmt.Errorf("service.GetSecretCode: %w", err)
+			}
+		}
 		return fmt.Errorf("service.GetSecretCode: %w", domain.ErrInvalidSecretCode)
 	}
+
+	// the code is single use, whoever deletes it first gets through
+	deleted, err := u.redis.Del(u.ctx, phone).Result()
+	if err != nil {
+		return fmt.Errorf("service.GetSecretCode: %w", err)
+	}
+
+	if deleted == 0 {
+		return fmt.Errorf("service.GetSecretCode: %w", domain.ErrInvalidSecretCode)
+	}
+
+	// a correct code is not a failed attempt
+	if err = u.redis.Del(u.ctx, otpPhoneAttemptsKey(phone)).Err(); err != nil {
+		return fmt.Errorf("service.GetSecretCode: %w", err)
+	}
+
 	return nil
 }
 
@@ -279,37 +404,217 @@ func (u *UserAuthService) GetUserInfo(id int) (*domain.User, error) {
 	return u.repos.GetUser(id)
 }
 
+func (u *UserAuthService) AttachEmail(userId int, email string) error {
+	user, err := u.repos.GetUser(userId)
+	if err != nil {
+		return fmt.Errorf("service.AttachEmail: %w", err)
+	}
+
+	if err = u.repos.SetEmail(userId, email); err != nil {
+		return fmt.Errorf("service.AttachEmail: %w", err)
+	}
+
+	token, err := u.tokenManager.NewRefreshToken()
+	if err != nil {
+		return fmt.Errorf("service.AttachEmail: %w", err)
+	}
+
+	err = u.redis.Set(u.ctx, emailVerificationKey(token), fmt.Sprintf("%d:%s", userId, email), u.emails.config.VerificationTTL).Err()
+	if err != nil {
+		return fmt.Errorf("service.AttachEmail: %w", err)
+	}
+
+	if err = u.emails.SendVerificationEmail(email, user.Name, token); err != nil {
+		return fmt.Errorf("service.AttachEmail: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserAuthService) VerifyEmail(token string) error {
+	value, err := u.redis.GetDel(u.ctx, emailVerificationKey(token)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return fmt.Errorf("service.VerifyEmail: %w", domain.ErrInvalidEmailToken)
+		}
+		return fmt.Errorf("service.VerifyEmail: %w", err)
+	}
+
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("service.VerifyEmail: %w", domain.ErrInvalidEmailToken)
+	}
+
+	userId, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return fmt.Errorf("service.VerifyEmail: %w", domain.ErrInvalidEmailToken)
+	}
+
+	// the address may have been replaced after this token was sent, then the update matches nothing
+	if err = u.repos.VerifyEmail(userId, parts[1]); err != nil {
+		return fmt.Errorf("service.VerifyEmail: %w", err)
+	}
+
+	return nil
+}
+
+func emailVerificationKey(token string) string {
+	return fmt.Sprintf("email_verification:%s", token)
+}
+
 func (u *UserAuthService) UpdateUserInfo(user domain.UserUpdate, id int) error {
 	return u.repos.UpdateUserInfo(user, id)
 }
 
-func (u *UserAuthService) createSession(userId int, userType string) (*Tokens, error) {
-	var (
-		res Tokens
-		err error
-	)
+func (u *UserAuthService) RefreshTokens(refreshToken string, device domain.Device) (*Tokens, error) {
+	session, err := u.repos.GetSessionByRefreshToken(refreshToken)
 
-	res.AccessToken, err = u.tokenManager.NewJWT(strconv.Itoa(userId), userType, u.accessTokenTTL)
 	if err != nil {
-		return nil, fmt.Errorf("service.createSession.NewJWT: %w", err)
+		rotated, rotatedErr := u.repos.GetSessionByRotatedToken(refreshToken)
+		if rotatedErr != nil {
+			return nil, fmt.Errorf("service.RefreshTokens: %w", err)
+		}
+
+		// a token that was already rotated out is being presented again,
+		// so somebody else holds a copy and the whole session is revoked
+		if err = u.RevokeSession(rotated.Id, rotated.UserId); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("service.RefreshTokens: %w", err)
+		}
+		return nil, fmt.Errorf("service.RefreshTokens: %w", domain.ErrRefreshTokenReused)
+	}
+
+	if session.ExpiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf("service.RefreshTokens: %w", domain.ErrRefreshTokenExpired)
+	}
+
+	refresh, err := u.tokenManager.NewRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("service.RefreshTokens: %w", err)
+	}
 
+	newSession := domain.Session{
+		RefreshToken: refresh,
+		ExpiresAt:    time.Now().Add(u.refreshTokenTTL),
+		Ip:           device.Ip,
+		UserAgent:    device.UserAgent,
 	}
 
+	err = u.repos.RotateSession(session.Id, refreshToken, newSession)
+
+	if err != nil {
+		return nil, fmt.Errorf("service.RefreshTokens: %w", err)
+	}
+
+	access, err := u.tokenManager.NewJWT(strconv.Itoa(session.UserId), session.UserType, strconv.Itoa(session.Id), u.accessTokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("service.RefreshTokens: %w", err)
+	}
+
+	return &Tokens{UserId: session.UserId, AccessToken: access, RefreshToken: refresh}, nil
+}
+
+func (u *UserAuthService) GetSessions(userId, currentSessionId int) ([]*domain.Session, error) {
+	sessions, err := u.repos.GetSessions(userId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetSessions: %w", err)
+	}
+
+	for _, session := range sessions {
+		session.IsCurrent = session.Id == currentSessionId
+	}
+
+	return sessions, nil
+}
+
+func (u *UserAuthService) RevokeSession(id, userId int) error {
+	if err := u.repos.RevokeSession(id, userId); err != nil {
+		return fmt.Errorf("service.RevokeSession: %w", err)
+	}
+
+	if err := u.denySessions(id); err != nil {
+		return fmt.Errorf("service.RevokeSession: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserAuthService) RevokeAllSessions(userId int) error {
+	ids, err := u.repos.RevokeAllSessions(userId)
+	if err != nil {
+		return fmt.Errorf("service.RevokeAllSessions: %w", err)
+	}
+
+	if err = u.denySessions(ids...); err != nil {
+		return fmt.Errorf("service.RevokeAllSessions: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserAuthService) IsSessionRevoked(sessionId int) (bool, error) {
+	count, err := u.redis.Exists(u.ctx, revokedSessionKey(sessionId)).Result()
+	if err != nil {
+		return false, fmt.Errorf("service.IsSessionRevoked: %w", err)
+	}
+
+	return count != 0, nil
+}
+
+// denySessions puts revoked sessions on the deny-list for as long as
+// access tokens issued for them may still be valid.
+func (u *UserAuthService) denySessions(ids ...int) error {
+	for _, id := range ids {
+		err := u.redis.Set(u.ctx, revokedSessionKey(id), true, u.accessTokenTTL).Err()
+		if err != nil {
+			return fmt.Errorf("service.denySessions: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (u *UserAuthService) createSession(userId int, userType string, device domain.Device) (*Tokens, error) {
+	var (
+		res = Tokens{UserId: userId}
+		err error
+	)
+
 	res.RefreshToken, err = u.tokenManager.NewRefreshToken()
 	if err != nil {
 		return nil, fmt.Errorf("service.createSession.NewRefreshToken: %w", err)
 	}
 
 	session := domain.Session{
+		UserId:       userId,
 		RefreshToken: res.RefreshToken,
 		ExpiresAt:    time.Now().Add(u.refreshTokenTTL),
+		DeviceName:   device.Name,
+		Ip:           device.Ip,
+		UserAgent:    device.UserAgent,
 	}
 
-	err = u.repos.SetSession(userId, session)
+	sessionId, err := u.repos.CreateSession(session)
 
 	if err != nil {
 		return nil, fmt.Errorf("service.createSession: %w", err)
 	}
 
+	res.AccessToken, err = u.tokenManager.NewJWT(strconv.Itoa(userId), userType, strconv.Itoa(sessionId), u.accessTokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("service.createSession.NewJWT: %w", err)
+	}
+
 	return &res, nil
 }
+
+func resetPasswordKey(phone string) string {
+	return fmt.Sprintf("reset_password:%s", phone)
+}
+
+func revokedSessionKey(sessionId int) string {
+	return fmt.Sprintf("revoked_session:%d", sessionId)
+}
+
+func (u *UserAuthService) GetManagerProfile(userId int) (*domain.ManagerProfile, error) {
+	return u.repos.GetManagerProfile(userId)
+}
@@ -8,15 +8,21 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+const suggestLimit = 10
+
 type BuildingService struct {
-	repos repository.Building
+	repos    repository.Building
+	schedule *ScheduleService
 }
 
-func NewBuildingService(repos repository.Building) *BuildingService {
-	return &BuildingService{repos: repos}
+func NewBuildingService(repos repository.Building, schedule *ScheduleService) *BuildingService {
+	return &BuildingService{repos: repos, schedule: schedule}
 }
 
 func (b *BuildingService) Create(ctx *fiber.Ctx, building domain.Building) (int, error) {
+	if building.Timezone == "" {
+		building.Timezone = domain.DefaultTimezone
+	}
 	return b.repos.Create(ctx, building)
 }
 func (b *BuildingService) GetAll(ctx *fiber.Ctx, page domain.Pagination, info domain.UserInfo, building domain.FilterForBuilding) (*domain.GetAllResponses, error) {
@@ -27,21 +33,51 @@ func (b *BuildingService) GetById(ctx *fiber.Ctx, info domain.UserInfo, id int)
 	return b.repos.GetById(ctx, info, id)
 }
 
-func (b *BuildingService) Update(ctx *fiber.Ctx, id int, inp domain.Building) error {
-	img, err := b.repos.Update(ctx, id, inp)
+// Update rebuilds the weekly hours of every day when the end time is given, the start
+// time is taken along with it since 0 is a valid start. A new timezone moves the hours
+// to its wall clock, so the upcoming orders are checked as for new hours. The building,
+// the hours and the orders they leave out are changed in one transaction.
+func (b *BuildingService) Update(ctx *fiber.Ctx, id int, inp domain.Building, resolution string) ([]*domain.ScheduleConflict, error) {
+	var (
+		conflicts []*domain.ScheduleConflict
+		days      []domain.BuildingHours
+		resolve   repository.ScheduleResolver
+	)
+
+	if inp.EndTime != 0 {
+		rules, err := b.schedule.GetBookingRules(id)
+		if err != nil {
+			return nil, fmt.Errorf("service.Update: %w", err)
+		}
+
+		days = domain.DefaultWeek(inp.StartTime, inp.EndTime, rules.SlotLength)
+
+		if err = checkWeek(days); err != nil {
+			return nil, fmt.Errorf("service.Update: %w", err)
+		}
+
+		resolve = weekResolver(id, resolution, &conflicts)
+	} else if inp.Timezone != "" {
+		resolve = conflictResolver(id, resolution, &conflicts)
+	}
+
+	img, err := b.repos.Update(ctx, id, inp, days, resolve)
 	if err != nil {
-		return fmt.Errorf("service.Update: %w", err)
+		return conflicts, fmt.Errorf("service.Update: %w", err)
 
 	}
+
+	b.schedule.notifyResolved(conflicts, resolution)
+
 	for i, _ := range img {
 		if img[i] != "" {
 			err = media.DeleteImage(img[i])
 			if err != nil {
-				return fmt.Errorf("service.Update: %w", err)
+				return nil, fmt.Errorf("service.Update: %w", err)
 			}
 		}
 	}
-	return nil
+	return conflicts, nil
 }
 
 func (b *BuildingService) Delete(ctx *fiber.Ctx, id int) error {
@@ -60,3 +96,16 @@ func (b *BuildingService) Delete(ctx *fiber.Ctx, id int) error {
 	}
 	return nil
 }
+
+func (b *BuildingService) Suggest(ctx *fiber.Ctx, filter domain.SuggestFilter) ([]*domain.BuildingSuggestion, error) {
+	if filter.Limit == 0 {
+		filter.Limit = suggestLimit
+	}
+
+	list, err := b.repos.Suggest(ctx, filter.Q, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("service.Suggest: %w", err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"carWash/internal/config"
+	"carWash/internal/domain"
+	"carWash/pkg/email"
+	"fmt"
+	"time"
+)
+
+type verificationEmailInput struct {
+	Name string
+	Code string
+	TTL  string
+}
+
+type purchaseSuccessfulInput struct {
+	Name         string
+	OrderId      int
+	BuildingName string
+	Address      string
+	Date         string
+	Time         string
+}
+
+type EmailService struct {
+	sender email.Sender
+	config config.EmailConfig
+}
+
+func NewEmailService(sender email.Sender, config config.EmailConfig) *EmailService {
+	return &EmailService{sender: sender, config: config}
+}
+
+func (e *EmailService) SendVerificationEmail(to, name, token string) error {
+	input := email.SendInput{
+		To:      to,
+		Subject: fmt.Sprintf(e.config.Subjects.Verification, name),
+	}
+
+	if err := input.GenerateBodyFromHTML(e.config.Templates.Verification, verificationEmailInput{
+		Name: name,
+		Code: token,
+		TTL:  e.config.VerificationTTL.String(),
+	}); err != nil {
+		return fmt.Errorf("service.SendVerificationEmail: %w", err)
+	}
+
+	if err := e.sender.Send(input); err != nil {
+		return fmt.Errorf("service.SendVerificationEmail: %w", err)
+	}
+
+	return nil
+}
+
+func (e *EmailService) SendPurchaseSuccessful(to string, order domain.Order) error {
+	input := email.SendInput{
+		To:      to,
+		Subject: e.config.Subjects.PurchaseSuccessful,
+	}
+
+	loc := domain.Location(order.Timezone)
+
+	start := time.Unix(int64(order.StartDate), 0).In(loc)
+	end := time.Unix(int64(order.EndDate), 0).In(loc)
+
+	if err := input.GenerateBodyFromHTML(e.config.Templates.PurchaseSuccessful, purchaseSuccessfulInput{
+		Name:         order.UserName,
+		OrderId:      order.Id,
+		BuildingName: order.BuildingName,
+		Address:      order.Address,
+		Date:         start.Format("02.01.2006"),
+		Time:         start.Format("15:04") + "–" + end.Format("15:04"),
+	}); err != nil {
+		return fmt.Errorf("service.SendPurchaseSuccessful: %w", err)
+	}
+
+	if err := e.sender.Send(input); err != nil {
+		return fmt.Errorf("service.SendPurchaseSuccessful: %w", err)
+	}
+
+	return nil
+}
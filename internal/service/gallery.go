@@ -0,0 +1,78 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"carWash/internal/repository"
+	"carWash/pkg/logger"
+	"carWash/pkg/media"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+)
+
+// galleryLimit is the number of images one building or pitch may have, the cover included.
+const galleryLimit = 20
+
+type GalleryService struct {
+	repos repository.Gallery
+}
+
+func NewGalleryService(repos repository.Gallery) *GalleryService {
+	return &GalleryService{repos: repos}
+}
+
+// AddImages stores the already saved files in the gallery, the files are removed again
+// when they can not be added.
+func (g *GalleryService) AddImages(ctx *fiber.Ctx, owner domain.ImageOwner, images []string) ([]*domain.Image, error) {
+	added, err := g.addImages(ctx, owner, images)
+
+	if err != nil {
+		for _, image := range images {
+			if mediaErr := media.DeleteImage(image); mediaErr != nil {
+				logger.Errorf("service.AddImages: %v", mediaErr)
+			}
+		}
+		return nil, fmt.Errorf("service.AddImages: %w", err)
+	}
+
+	return added, nil
+}
+
+func (g *GalleryService) addImages(ctx *fiber.Ctx, owner domain.ImageOwner, images []string) ([]*domain.Image, error) {
+	gallery, err := g.repos.GetImages(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(gallery)+len(images) > galleryLimit {
+		return nil, domain.ErrTooManyImages
+	}
+
+	return g.repos.AddImages(ctx, owner, images)
+}
+
+func (g *GalleryService) SetImagesOrder(ctx *fiber.Ctx, owner domain.ImageOwner, ids []int) error {
+	if err := g.repos.SetImagesOrder(ctx, owner, ids); err != nil {
+		return fmt.Errorf("service.SetImagesOrder: %w", err)
+	}
+	return nil
+}
+
+func (g *GalleryService) SetCover(ctx *fiber.Ctx, owner domain.ImageOwner, imageId int) error {
+	if err := g.repos.SetCover(ctx, owner, imageId); err != nil {
+		return fmt.Errorf("service.SetCover: %w", err)
+	}
+	return nil
+}
+
+func (g *GalleryService) DeleteImage(ctx *fiber.Ctx, owner domain.ImageOwner, imageId int) error {
+	image, err := g.repos.DeleteImage(ctx, owner, imageId)
+	if err != nil {
+		return fmt.Errorf("service.DeleteImage: %w", err)
+	}
+
+	if err = media.DeleteImage(image); err != nil {
+		return fmt.Errorf("service.DeleteImage: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// guestUserType is put into guest access tokens instead of the account's user_type,
+// guests may book and see their orders but nothing else.
+const guestUserType = "guest"
+
+// GuestSendCode starts the guest checkout, phones of full accounts have to sign in instead.
+func (u *UserAuthService) GuestSendCode(phone, language string) (string, error) {
+	if _, err := u.repos.VerifyExistenceUser(phone, true); err == nil {
+		return "", fmt.Errorf("service.GuestSendCode: %w", domain.ErrUserAlreadyExist)
+	}
+
+	code, err := u.SetSecretCode(phone, language)
+	if err != nil {
+		return "", fmt.Errorf("service.GuestSendCode: %w", err)
+	}
+
+	return code, nil
+}
+
+// GuestVerify checks the code and issues a short-lived guest access token, the unactivated
+// account is created on the first booking and reused by the following ones.
+func (u *UserAuthService) GuestVerify(input domain.GuestVerifyInput, device domain.Device, ip string) (*Tokens, error) {
+	if err := u.GetSecretCode(input.SecretCode, input.PhoneNumber, ip); err != nil {
+		return nil, fmt.Errorf("service.GuestVerify: %w", err)
+	}
+
+	if _, err := u.repos.VerifyExistenceUser(input.PhoneNumber, true); err == nil {
+		return nil, fmt.Errorf("service.GuestVerify: %w", domain.ErrUserAlreadyExist)
+	}
+
+	var userId int
+
+	guest, err := u.repos.VerifyExistenceUser(input.PhoneNumber, false)
+
+	if err != nil {
+		userId, err = u.repos.CreateUser(domain.User{
+			Name:        input.Name,
+			PhoneNumber: input.PhoneNumber,
+			UserType:    "user",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("service.GuestVerify: %w", err)
+		}
+	} else {
+		userId = guest.Id
+	}
+
+	return u.createGuestSession(userId, device)
+}
+
+// ClaimGuest sets a password on the guest account, the phone was verified when the guest
+// token was issued so no new code is needed. Orders stay with the account since its id is kept.
+func (u *UserAuthService) ClaimGuest(userId, sessionId int, input domain.ClaimGuestInput, device domain.Device) (*Tokens, error) {
+	if input.Password != input.ConfirmPassword {
+		return nil, fmt.Errorf("service.ClaimGuest: %w", domain.ErrPasswordNotMatch)
+	}
+
+	hashedPassword, err := u.hashes.Hash(input.Password)
+	if err != nil {
+		return nil, fmt.Errorf("service.ClaimGuest: %w", err)
+	}
+
+	if err = u.repos.ClaimGuest(userId, input.Name, hashedPassword); err != nil {
+		return nil, fmt.Errorf("service.ClaimGuest: %w", err)
+	}
+
+	if err = u.RevokeSession(sessionId, userId); err != nil {
+		return nil, fmt.Errorf("service.ClaimGuest: %w", err)
+	}
+
+	return u.createSession(userId, "user", device)
+}
+
+// createGuestSession stores a session so the guest token can be revoked, its refresh
+// token is never handed out and could not be used anyway while the account is unactivated.
+func (u *UserAuthService) createGuestSession(userId int, device domain.Device) (*Tokens, error) {
+	refreshToken, err := u.tokenManager.NewRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("service.createGuestSession: %w", err)
+	}
+
+	sessionId, err := u.repos.CreateSession(domain.Session{
+		UserId:       userId,
+		RefreshToken: refreshToken,
+		ExpiresAt:    time.Now().Add(u.guestTokenTTL),
+		DeviceName:   device.Name,
+		Ip:           device.Ip,
+		UserAgent:    device.UserAgent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service.createGuestSession: %w", err)
+	}
+
+	access, err := u.tokenManager.NewJWT(strconv.Itoa(userId), guestUserType, strconv.Itoa(sessionId), u.guestTokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("service.createGuestSession: %w", err)
+	}
+
+	return &Tokens{UserId: userId, AccessToken: access}, nil
+}
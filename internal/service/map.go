@@ -0,0 +1,47 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"math"
+)
+
+const (
+	// mapClusterZoom is the first zoom level the buildings are shown one by one
+	mapClusterZoom = 14
+	// mapCellsPerTile splits every 256px map tile into cells of about 64px
+	mapCellsPerTile = 4
+)
+
+// GetMap returns the buildings inside the viewport, on low zoom levels they are grouped
+// into clusters so the map does not have to load every building.
+func (b *BuildingService) GetMap(ctx *fiber.Ctx, filter domain.MapFilter) (*domain.MapResponse, error) {
+	res := domain.MapResponse{
+		Buildings: make([]*domain.MapBuilding, 0),
+		Clusters:  make([]*domain.MapCluster, 0),
+	}
+
+	if *filter.Zoom >= mapClusterZoom {
+		buildings, err := b.repos.GetMapBuildings(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("service.GetMap: %w", err)
+		}
+
+		res.Buildings = buildings
+
+		return &res, nil
+	}
+
+	cell := 360 / math.Pow(2, float64(*filter.Zoom)) / mapCellsPerTile
+
+	clusters, err := b.repos.GetMapClusters(ctx, filter, cell)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetMap: %w", err)
+	}
+
+	res.Clustered = true
+	res.Clusters = clusters
+
+	return &res, nil
+}
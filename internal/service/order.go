@@ -3,26 +3,235 @@ package service
 import (
 	"carWash/internal/domain"
 	"carWash/internal/repository"
+	"carWash/pkg/logger"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"time"
 )
 
 type OrderService struct {
-	repos repository.Order
+	repos    repository.Order
+	users    repository.UserAuth
+	schedule *ScheduleService
+	emails   *EmailService
 }
 
+// GetAllBookTime lists the slots of the order date from the building schedule in its local
+// time, a request without a date gets today. The slots have the length set for the pitch when
+// it is given.
 func (o *OrderService) GetAllBookTime(ctx *fiber.Ctx, times domain.FilterForOrderTimes) (*domain.GetAllResponses, error) {
-	return o.repos.GetAllBookTime(ctx, times)
+	loc, err := o.schedule.GetLocation(times.BuildingId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetAllBookTime: %w", err)
+	}
+
+	date, err := businessDate(times.Date, times.OrderDate, loc)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetAllBookTime: %w", err)
+	}
+
+	hours, err := o.schedule.GetDayHours(times.BuildingId, date)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetAllBookTime: %w", err)
+	}
+
+	var (
+		booked     []*domain.BookedInterval
+		slotLength int
+	)
+
+	if times.PitchId != 0 {
+		rules, err := o.schedule.GetPitchBookingRules(times.PitchId)
+		if err != nil {
+			return nil, fmt.Errorf("service.GetAllBookTime: %w", err)
+		}
+
+		slotLength = rules.SlotLength
+
+		from := float64(localTime(date, hours.Open, loc).Unix())
+		to := float64(localTime(date, hours.Close, loc).Unix())
+
+		booked, err = o.repos.GetBookedIntervals(ctx, times.PitchId, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("service.GetAllBookTime: %w", err)
+		}
+	} else {
+		rules, err := o.schedule.GetBookingRules(times.BuildingId)
+		if err != nil {
+			return nil, fmt.Errorf("service.GetAllBookTime: %w", err)
+		}
+
+		slotLength = rules.SlotLength
+	}
+
+	slots := daySlots(*hours, slotLength)
+
+	inp := make([]*domain.OrderTime, 0, len(slots))
+
+	for _, start := range slots {
+		from := localTime(date, start, loc)
+		to := localTime(date, start+slotLength, loc)
+
+		// the clock skips the slot on a DST change
+		if !to.After(from) {
+			continue
+		}
+
+		inp = append(inp, &domain.OrderTime{
+			Date:      from.Format("2006-01-02"),
+			WorkTime:  formatClock(start),
+			StartDate: float64(from.Unix()),
+			EndDate:   float64(to.Unix()),
+			StartAt:   from.Format(time.RFC3339),
+			EndAt:     to.Format(time.RFC3339),
+			IsBooked:  isBooked(booked, float64(from.Unix()), float64(to.Unix())),
+		})
+	}
+
+	return &domain.GetAllResponses{Data: inp}, nil
 }
 
-func NewOrderService(repos repository.Order) *OrderService {
-	return &OrderService{repos: repos}
+func isBooked(booked []*domain.BookedInterval, from, to float64) bool {
+	for _, interval := range booked {
+		if interval.Start < to && interval.End > from {
+			return true
+		}
+	}
+	return false
+}
+
+func NewOrderService(repos repository.Order, users repository.UserAuth, schedule *ScheduleService, emails *EmailService) *OrderService {
+	return &OrderService{repos: repos, users: users, schedule: schedule, emails: emails}
 }
 
 func (o *OrderService) Create(ctx *fiber.Ctx, order domain.Order) (int, error) {
-	return o.repos.Create(ctx, order)
+	if err := o.setInterval(&order); err != nil {
+		return 0, fmt.Errorf("service.Create: %w", err)
+	}
+
+	id, err := o.repos.Create(ctx, order)
+	if err != nil {
+		return 0, err
+	}
+
+	// the booking is already stored, a failed confirmation must not fail the request
+	if err = o.sendConfirmation(ctx, id, order.UserId); err != nil {
+		logger.Error(fmt.Errorf("service.Create: %w", err))
+	}
+
+	return id, nil
+}
+
+// Block takes times of the pitch for a booking made outside the app, by the venue or an
+// integration. It follows the same rules as an order but nobody is sent a confirmation.
+func (o *OrderService) Block(ctx *fiber.Ctx, order domain.Order) (int, error) {
+	if err := o.setInterval(&order); err != nil {
+		return 0, fmt.Errorf("service.Block: %w", err)
+	}
+
+	id, err := o.repos.Create(ctx, order)
+	if err != nil {
+		return 0, fmt.Errorf("service.Block: %w", err)
+	}
+
+	return id, nil
+}
+
+// setInterval checks the start and end time of the order against the pitch rules and the
+// hours of the order date and sets the interval in unix seconds. The order has to take whole
+// slots counted from the opening time. The order date is the business day, times before the
+// opening are after midnight of a day that runs into the next one. All times are local to
+// the building.
+func (o *OrderService) setInterval(order *domain.Order) error {
+	rules, err := o.schedule.GetPitchBookingRules(order.PitchId)
+	if err != nil {
+		return err
+	}
+
+	start, err := parseClock(order.StartTime)
+	if err != nil {
+		return domain.ErrInvalidBookingTime
+	}
+
+	end, err := parseClock(order.EndTime)
+	if err != nil {
+		return domain.ErrInvalidBookingTime
+	}
+
+	loc, err := o.schedule.GetLocation(rules.BuildingId)
+	if err != nil {
+		return err
+	}
+
+	date, err := businessDate(order.Date, order.OrderDate, loc)
+	if err != nil {
+		return domain.ErrInvalidBookingTime
+	}
+
+	hours, err := o.schedule.GetDayHours(rules.BuildingId, date)
+	if err != nil {
+		return err
+	}
+
+	if start < hours.Open {
+		start += 24 * 60
+	}
+
+	if end <= start {
+		end += 24 * 60
+	}
+
+	if hours.IsClosed || start < hours.Open || end > hours.Close ||
+		(start-hours.Open)%rules.SlotLength != 0 || (end-start)%rules.SlotLength != 0 {
+		return domain.ErrInvalidBookingTime
+	}
+
+	duration := end - start
+
+	if duration < rules.MinDuration || (rules.MaxDuration != nil && duration > *rules.MaxDuration) {
+		return domain.ErrBookingDuration
+	}
+
+	from, to := localTime(date, start, loc), localTime(date, end, loc)
+
+	if !to.After(from) {
+		return domain.ErrInvalidBookingTime
+	}
+
+	order.OrderDate = float64(date.Unix())
+	order.StartDate = float64(from.Unix())
+	order.EndDate = float64(to.Unix())
+
+	return nil
+}
+
+func (o *OrderService) sendConfirmation(ctx *fiber.Ctx, id, userId int) error {
+	user, err := o.users.GetUser(userId)
+	if err != nil {
+		return err
+	}
+
+	if user.Email == "" || !user.EmailVerified {
+		return nil
+	}
+
+	order, err := o.repos.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return o.emails.SendPurchaseSuccessful(user.Email, *order)
 }
 
+// GetAll filters by the business date, it is stored as UTC midnight of the date.
 func (o *OrderService) GetAll(ctx *fiber.Ctx, page domain.Pagination, info domain.UserInfo, order domain.FilterForOrder) (*domain.GetAllResponses, error) {
+	if order.Date != "" {
+		date, err := time.Parse("2006-01-02", order.Date)
+		if err != nil {
+			return nil, fmt.Errorf("service.GetAll: %w", err)
+		}
+		order.OrderDate = float64(date.Unix())
+	}
 	return o.repos.GetAll(ctx, page, info, order)
 }
 
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"carWash/internal/repository"
+	"errors"
+	"testing"
+	"time"
+)
+
+// scheduleStub serves the rules and hours setInterval reads, the rest of the schedule is not used.
+type scheduleStub struct {
+	repository.Schedule
+	rules    domain.PitchBookingRules
+	timezone string
+	hours    map[string]domain.BuildingHours
+}
+
+func (s *scheduleStub) GetPitchBookingRules(pitchId int) (*domain.PitchBookingRules, error) {
+	rules := s.rules
+	return &rules, nil
+}
+
+func (s *scheduleStub) GetTimezone(buildingId int) (string, error) {
+	return s.timezone, nil
+}
+
+func (s *scheduleStub) GetDayHours(buildingId int, date string, weekday int) (*domain.BuildingHours, error) {
+	hours, ok := s.hours[date]
+	if !ok {
+		return &domain.BuildingHours{Weekday: weekday, IsClosed: true}, nil
+	}
+	return &hours, nil
+}
+
+func TestSetInterval(t *testing.T) {
+	maxDuration := 120
+
+	schedule := &scheduleStub{
+		rules: domain.PitchBookingRules{
+			BuildingId:   1,
+			BookingRules: domain.BookingRules{SlotLength: 30, MinDuration: 60, MaxDuration: &maxDuration},
+		},
+		timezone: "Asia/Almaty",
+		hours: map[string]domain.BuildingHours{
+			"2024-05-20": {Weekday: 1, OpenTime: "22:00", CloseTime: "02:00"},
+			"2024-05-21": {Weekday: 2, OpenTime: "08:00", CloseTime: "20:00"},
+		},
+	}
+
+	orders := &OrderService{schedule: &ScheduleService{repos: schedule}}
+
+	tests := []struct {
+		name      string
+		date      string
+		start     string
+		end       string
+		wantStart string
+		wantEnd   string
+		wantErr   error
+	}{
+		{"overnight", "2024-05-20", "23:00", "01:00", "2024-05-20T18:00:00Z", "2024-05-20T20:00:00Z", nil},
+		{"after midnight", "2024-05-20", "00:30", "01:30", "2024-05-20T19:30:00Z", "2024-05-20T20:30:00Z", nil},
+		{"till close", "2024-05-20", "01:00", "02:00", "2024-05-20T20:00:00Z", "2024-05-20T21:00:00Z", nil},
+		{"day hours", "2024-05-21", "08:00", "09:30", "2024-05-21T03:00:00Z", "2024-05-21T04:30:00Z", nil},
+		{"past close", "2024-05-20", "01:30", "02:30", "", "", domain.ErrInvalidBookingTime},
+		{"before open", "2024-05-20", "10:00", "11:00", "", "", domain.ErrInvalidBookingTime},
+		{"misaligned start", "2024-05-20", "22:15", "23:15", "", "", domain.ErrInvalidBookingTime},
+		{"misaligned length", "2024-05-21", "08:00", "09:10", "", "", domain.ErrInvalidBookingTime},
+		{"shorter than min duration", "2024-05-20", "22:00", "22:30", "", "", domain.ErrBookingDuration},
+		{"longer than max duration", "2024-05-20", "22:00", "01:00", "", "", domain.ErrBookingDuration},
+		{"closed day", "2024-05-22", "10:00", "11:00", "", "", domain.ErrInvalidBookingTime},
+		{"malformed time", "2024-05-21", "25:00", "26:00", "", "", domain.ErrInvalidBookingTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := domain.Order{PitchId: 1, Date: tt.date, StartTime: tt.start, EndTime: tt.end}
+
+			err := orders.setInterval(&order)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("setInterval() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("setInterval() error = %v", err)
+			}
+
+			start := time.Unix(int64(order.StartDate), 0).UTC().Format(time.RFC3339)
+			end := time.Unix(int64(order.EndDate), 0).UTC().Format(time.RFC3339)
+
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("setInterval() = %s - %s, want %s - %s", start, end, tt.wantStart, tt.wantEnd)
+			}
+
+			// the order stays on the business date it was booked for, also after midnight
+			if date := time.Unix(int64(order.OrderDate), 0).UTC().Format(time.RFC3339); date != tt.date+"T00:00:00Z" {
+				t.Errorf("setInterval() order date = %s, want %s", date, tt.date)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"fmt"
+	"time"
+)
+
+type OTPPolicy struct {
+	MaxAttempts    int
+	IPMaxAttempts  int
+	Lockout        time.Duration
+	ResendCooldown time.Duration
+}
+
+func otpPhoneAttemptsKey(phone string) string {
+	return fmt.Sprintf("otp_attempts:phone:%s", phone)
+}
+
+func otpIPAttemptsKey(ip string) string {
+	return fmt.Sprintf("otp_attempts:ip:%s", ip)
+}
+
+func otpResendKey(phone string) string {
+	return fmt.Sprintf("otp_resend:%s", phone)
+}
+
+// reserveOTPAttempt counts the attempt before the code is checked, so parallel guesses
+// cannot get past the limits, and returns the attempts of the phone. A RetryAfterError is
+// returned once the phone or the ip has used up its attempts, Redis errors refuse the attempt too.
+func (u *UserAuthService) reserveOTPAttempt(phone, ip string) (int64, error) {
+	var phoneAttempts int64
+
+	limits := []struct {
+		key string
+		max int
+	}{
+		{otpPhoneAttemptsKey(phone), u.otp.MaxAttempts},
+		{otpIPAttemptsKey(ip), u.otp.IPMaxAttempts},
+	}
+
+	for i, limit := range limits {
+		count, err := u.redis.Incr(u.ctx, limit.key).Result()
+		if err != nil {
+			return 0, fmt.Errorf("service.reserveOTPAttempt: %w", err)
+		}
+
+		if count == 1 {
+			if err = u.redis.Expire(u.ctx, limit.key, u.otp.Lockout).Err(); err != nil {
+				return 0, fmt.Errorf("service.reserveOTPAttempt: %w", err)
+			}
+		}
+
+		if count > int64(limit.max) {
+			ttl, err := u.redis.TTL(u.ctx, limit.key).Result()
+			if err != nil {
+				return 0, fmt.Errorf("service.reserveOTPAttempt: %w", err)
+			}
+			return 0, &domain.RetryAfterError{Err: domain.ErrTooManyAttempts, RetryAfter: ttl}
+		}
+
+		if i == 0 {
+			phoneAttempts = count
+		}
+	}
+
+	return phoneAttempts, nil
+}
+
+// reserveOTPResend allows one code per phone within the resend cooldown.
+func (u *UserAuthService) reserveOTPResend(phone string) error {
+	ok, err := u.redis.SetNX(u.ctx, otpResendKey(phone), true, u.otp.ResendCooldown).Result()
+	if err != nil {
+		return fmt.Errorf("service.reserveOTPResend: %w", err)
+	}
+
+	if !ok {
+		ttl, err := u.redis.TTL(u.ctx, otpResendKey(phone)).Result()
+		if err != nil {
+			return fmt.Errorf("service.reserveOTPResend: %w", err)
+		}
+		return &domain.RetryAfterError{Err: domain.ErrSecretCodeCooldown, RetryAfter: ttl}
+	}
+
+	return nil
+}
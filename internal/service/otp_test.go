@@ -0,0 +1,243 @@
+package service
+
+import (
+	"bufio"
+	"carWash/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// fakeRedis answers the few commands the OTP and 2FA limits send, a client gets it through the dialer.
+type fakeRedis struct {
+	mu      sync.Mutex
+	values  map[string]string
+	expires map[string]time.Time
+}
+
+func newFakeRedis(t *testing.T) *redis.Client {
+	f := &fakeRedis{values: map[string]string{}, expires: map[string]time.Time{}}
+
+	client := redis.NewClient(&redis.Options{
+		Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			server, conn := net.Pipe()
+			go f.serve(server)
+			return conn, nil
+		},
+	})
+
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+
+		if _, err = conn.Write([]byte(f.exec(args))); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, n)
+
+	for i := range args {
+		if _, err = r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+
+		arg, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+
+	return args, nil
+}
+
+func (f *fakeRedis) exec(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for key, at := range f.expires {
+		if !time.Now().Before(at) {
+			delete(f.values, key)
+			delete(f.expires, key)
+		}
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "incr":
+		count, _ := strconv.ParseInt(f.values[args[1]], 10, 64)
+		count++
+		f.values[args[1]] = strconv.FormatInt(count, 10)
+		return fmt.Sprintf(":%d\r\n", count)
+	case "del":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := f.values[key]; ok {
+				delete(f.values, key)
+				delete(f.expires, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	case "expire":
+		if _, ok := f.values[args[1]]; !ok {
+			return ":0\r\n"
+		}
+		seconds, _ := strconv.Atoi(args[2])
+		f.expires[args[1]] = time.Now().Add(time.Duration(seconds) * time.Second)
+		return ":1\r\n"
+	case "ttl":
+		if _, ok := f.values[args[1]]; !ok {
+			return ":-2\r\n"
+		}
+		at, ok := f.expires[args[1]]
+		if !ok {
+			return ":-1\r\n"
+		}
+		return fmt.Sprintf(":%d\r\n", int(time.Until(at).Round(time.Second).Seconds()))
+	case "set":
+		var (
+			nx  bool
+			ttl time.Duration
+		)
+
+		for i := 3; i < len(args); i++ {
+			switch strings.ToLower(args[i]) {
+			case "nx":
+				nx = true
+			case "ex":
+				seconds, _ := strconv.Atoi(args[i+1])
+				ttl = time.Duration(seconds) * time.Second
+				i++
+			}
+		}
+
+		if _, ok := f.values[args[1]]; ok && nx {
+			return "$-1\r\n"
+		}
+
+		f.values[args[1]] = args[2]
+		delete(f.expires, args[1])
+
+		if ttl > 0 {
+			f.expires[args[1]] = time.Now().Add(ttl)
+		}
+		return "+OK\r\n"
+	}
+
+	return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+}
+
+func TestReserveOTPAttempt(t *testing.T) {
+	u := &UserAuthService{
+		redis: newFakeRedis(t),
+		ctx:   context.Background(),
+		otp:   OTPPolicy{MaxAttempts: 3, IPMaxAttempts: 5, Lockout: 15 * time.Minute},
+	}
+
+	tests := []struct {
+		name    string
+		phone   string
+		ip      string
+		want    int64
+		wantErr error
+	}{
+		{"first attempt", "+77010000001", "10.0.0.1", 1, nil},
+		{"second attempt", "+77010000001", "10.0.0.1", 2, nil},
+		{"last attempt of the phone", "+77010000001", "10.0.0.1", 3, nil},
+		{"phone used up its attempts", "+77010000001", "10.0.0.1", 0, domain.ErrTooManyAttempts},
+		{"other phone from the same ip", "+77010000002", "10.0.0.1", 1, nil},
+		{"last attempt of the ip", "+77010000002", "10.0.0.1", 2, nil},
+		{"ip used up its attempts", "+77010000002", "10.0.0.1", 0, domain.ErrTooManyAttempts},
+		{"other phone from another ip", "+77010000003", "10.0.0.2", 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := u.reserveOTPAttempt(tt.phone, tt.ip)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("reserveOTPAttempt() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("reserveOTPAttempt() = %d, want %d", got, tt.want)
+			}
+
+			var retryErr *domain.RetryAfterError
+			if tt.wantErr != nil {
+				if !errors.As(err, &retryErr) {
+					t.Fatalf("reserveOTPAttempt() error = %v, want a RetryAfterError", err)
+				}
+
+				if retryErr.RetryAfter <= 0 || retryErr.RetryAfter > u.otp.Lockout {
+					t.Errorf("RetryAfter = %v, want within the lockout %v", retryErr.RetryAfter, u.otp.Lockout)
+				}
+			}
+		})
+	}
+}
+
+func TestReserveOTPResend(t *testing.T) {
+	u := &UserAuthService{
+		redis: newFakeRedis(t),
+		ctx:   context.Background(),
+		otp:   OTPPolicy{ResendCooldown: time.Minute},
+	}
+
+	tests := []struct {
+		name    string
+		phone   string
+		wantErr error
+	}{
+		{"first code", "+77010000001", nil},
+		{"within the cooldown", "+77010000001", domain.ErrSecretCodeCooldown},
+		{"other phone", "+77010000002", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.reserveOTPResend(tt.phone)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("reserveOTPResend() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var retryErr *domain.RetryAfterError
+			if tt.wantErr != nil && (!errors.As(err, &retryErr) || retryErr.RetryAfter <= 0 || retryErr.RetryAfter > u.otp.ResendCooldown) {
+				t.Errorf("reserveOTPResend() error = %v, want a RetryAfterError within the cooldown", err)
+			}
+		})
+	}
+}
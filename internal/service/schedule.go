@@ -0,0 +1,616 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"carWash/internal/repository"
+	"carWash/pkg/logger"
+	"carWash/pkg/sms"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ScheduleService struct {
+	repos        repository.Schedule
+	smsSender    sms.Sender
+	smsTemplates *sms.Templates
+}
+
+func NewScheduleService(repos repository.Schedule, smsSender sms.Sender, smsTemplates *sms.Templates) *ScheduleService {
+	return &ScheduleService{repos: repos, smsSender: smsSender, smsTemplates: smsTemplates}
+}
+
+// GetLocation returns the timezone the hours of the building are in.
+func (s *ScheduleService) GetLocation(buildingId int) (*time.Location, error) {
+	timezone, err := s.repos.GetTimezone(buildingId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetLocation: %w", err)
+	}
+	return domain.Location(timezone), nil
+}
+
+// GetSchedule returns the booking rules, the weekly hours and the exceptions that are still ahead.
+func (s *ScheduleService) GetSchedule(buildingId int) (*domain.BuildingSchedule, error) {
+	booking, err := s.repos.GetBookingRules(buildingId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetSchedule: %w", err)
+	}
+
+	loc, err := s.GetLocation(buildingId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetSchedule: %w", err)
+	}
+
+	week, err := s.repos.GetWeek(buildingId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetSchedule: %w", err)
+	}
+
+	exceptions, err := s.repos.GetExceptions(buildingId, time.Now().In(loc).Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("service.GetSchedule: %w", err)
+	}
+
+	return &domain.BuildingSchedule{Booking: booking, Week: week, Exceptions: exceptions}, nil
+}
+
+func (s *ScheduleService) GetBookingRules(buildingId int) (*domain.BookingRules, error) {
+	rules, err := s.repos.GetBookingRules(buildingId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetBookingRules: %w", err)
+	}
+	return rules, nil
+}
+
+func (s *ScheduleService) GetPitchBookingRules(pitchId int) (*domain.PitchBookingRules, error) {
+	rules, err := s.repos.GetPitchBookingRules(pitchId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetPitchBookingRules: %w", err)
+	}
+	return rules, nil
+}
+
+// SetBookingRules changes the rules of the building, the booked orders are not touched.
+func (s *ScheduleService) SetBookingRules(buildingId int, rules domain.BookingRules) error {
+	if err := checkBookingRules(rules); err != nil {
+		return fmt.Errorf("service.SetBookingRules: %w", err)
+	}
+
+	if err := s.repos.SetBookingRules(buildingId, rules); err != nil {
+		return fmt.Errorf("service.SetBookingRules: %w", err)
+	}
+
+	return nil
+}
+
+// SetPitchBookingRules replaces the overrides of the pitch, they are checked together
+// with the building rules they are combined with.
+func (s *ScheduleService) SetPitchBookingRules(pitchId int, rules domain.PitchBookingRulesInput) error {
+	current, err := s.repos.GetPitchBookingRules(pitchId)
+	if err != nil {
+		return fmt.Errorf("service.SetPitchBookingRules: %w", err)
+	}
+
+	building, err := s.repos.GetBookingRules(current.BuildingId)
+	if err != nil {
+		return fmt.Errorf("service.SetPitchBookingRules: %w", err)
+	}
+
+	combined := *building
+
+	if rules.SlotLength != nil {
+		combined.SlotLength = *rules.SlotLength
+	}
+
+	if rules.MinDuration != nil {
+		combined.MinDuration = *rules.MinDuration
+	}
+
+	if rules.MaxDuration != nil {
+		combined.MaxDuration = rules.MaxDuration
+	}
+
+	if err = checkBookingRules(combined); err != nil {
+		return fmt.Errorf("service.SetPitchBookingRules: %w", err)
+	}
+
+	if err = s.repos.SetPitchBookingRules(pitchId, rules); err != nil {
+		return fmt.Errorf("service.SetPitchBookingRules: %w", err)
+	}
+
+	return nil
+}
+
+// checkBookingRules makes sure the durations are whole numbers of slots.
+func checkBookingRules(rules domain.BookingRules) error {
+	if rules.MinDuration%rules.SlotLength != 0 {
+		return domain.ErrInvalidBookingRules
+	}
+
+	if rules.MaxDuration != nil && (*rules.MaxDuration%rules.SlotLength != 0 || *rules.MaxDuration < rules.MinDuration) {
+		return domain.ErrInvalidBookingRules
+	}
+
+	return nil
+}
+
+// SetWeek changes the hours of the given weekdays, the other days stay as they are.
+// Upcoming orders left outside the new hours are returned, they are kept or cancelled
+// depending on the resolution and without one nothing is changed. The orders are checked
+// in the transaction that changes the hours.
+func (s *ScheduleService) SetWeek(buildingId int, days []domain.BuildingHours, resolution string) ([]*domain.ScheduleConflict, error) {
+	if err := checkWeek(days); err != nil {
+		return nil, fmt.Errorf("service.SetWeek: %w", err)
+	}
+
+	var conflicts []*domain.ScheduleConflict
+
+	if err := s.repos.SetWeek(buildingId, days, weekResolver(buildingId, resolution, &conflicts)); err != nil {
+		return conflicts, fmt.Errorf("service.SetWeek: %w", err)
+	}
+
+	s.notifyResolved(conflicts, resolution)
+
+	return conflicts, nil
+}
+
+// checkWeek validates the hours of the days, closed days get the whole day as their hours.
+func checkWeek(days []domain.BuildingHours) error {
+	seen := make(map[int]bool, len(days))
+
+	for i, day := range days {
+		if seen[day.Weekday] {
+			return domain.ErrInvalidSchedule
+		}
+		seen[day.Weekday] = true
+
+		if day.IsClosed {
+			days[i].OpenTime, days[i].CloseTime = "00:00", "24:00"
+			continue
+		}
+
+		if _, _, err := parseHours(day.OpenTime, day.CloseTime); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// weekResolver checks the week for overlapping days and then resolves the conflicts as
+// conflictResolver does, it is used wherever the weekly hours change.
+func weekResolver(buildingId int, resolution string, conflicts *[]*domain.ScheduleConflict) repository.ScheduleResolver {
+	resolve := conflictResolver(buildingId, resolution, conflicts)
+
+	return func(schedule repository.ScheduleReader) ([]int, error) {
+		if err := checkOvernight(schedule, buildingId); err != nil {
+			return nil, err
+		}
+		return resolve(schedule)
+	}
+}
+
+// conflictResolver finds the upcoming orders the changed schedule leaves out and stores them
+// in conflicts, the ones to cancel are picked by the resolution.
+func conflictResolver(buildingId int, resolution string, conflicts *[]*domain.ScheduleConflict) repository.ScheduleResolver {
+	return func(schedule repository.ScheduleReader) ([]int, error) {
+		found, err := findConflicts(schedule, buildingId)
+		if err != nil {
+			return nil, err
+		}
+
+		*conflicts = found
+
+		return resolveConflicts(found, resolution)
+	}
+}
+
+// checkOvernight refuses a day that runs past midnight into the hours of the next day.
+func checkOvernight(schedule repository.ScheduleReader, buildingId int) error {
+	current, err := schedule.GetWeek(buildingId)
+	if err != nil {
+		return err
+	}
+
+	week := make(map[int]domain.BuildingHours, 7)
+
+	for _, day := range current {
+		week[day.Weekday] = *day
+	}
+
+	// any week has every weekday, the dates only pick them
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 7; i++ {
+		date := monday.AddDate(0, 0, i)
+
+		if runsIntoNext(hoursOn(date, week, nil), hoursOn(date.AddDate(0, 0, 1), week, nil)) {
+			return domain.ErrInvalidSchedule
+		}
+	}
+
+	return nil
+}
+
+// checkExceptionOvernight applies the rule of checkOvernight to the exception date, it must
+// not run into the next day and the day before must not run into it.
+func checkExceptionOvernight(schedule repository.ScheduleReader, buildingId int, exceptionDate string) error {
+	date, err := time.Parse("2006-01-02", exceptionDate)
+	if err != nil {
+		return domain.ErrInvalidSchedule
+	}
+
+	current, err := schedule.GetWeek(buildingId)
+	if err != nil {
+		return err
+	}
+
+	week := make(map[int]domain.BuildingHours, 7)
+
+	for _, day := range current {
+		week[day.Weekday] = *day
+	}
+
+	currentExceptions, err := schedule.GetExceptions(buildingId, date.AddDate(0, 0, -1).Format("2006-01-02"))
+	if err != nil {
+		return err
+	}
+
+	exceptions := make(map[string]domain.ScheduleException, len(currentExceptions))
+
+	for _, val := range currentExceptions {
+		exceptions[val.Date] = *val
+	}
+
+	day := hoursOn(date, week, exceptions)
+
+	if runsIntoNext(hoursOn(date.AddDate(0, 0, -1), week, exceptions), day) ||
+		runsIntoNext(day, hoursOn(date.AddDate(0, 0, 1), week, exceptions)) {
+		return domain.ErrInvalidSchedule
+	}
+
+	return nil
+}
+
+// runsIntoNext reports whether the day runs past midnight into the hours of the next day.
+func runsIntoNext(day, next domain.DayHours) bool {
+	return !day.IsClosed && !next.IsClosed && day.Close > 24*60 && next.Open < day.Close-24*60
+}
+
+// SetException changes the hours of one date, conflicts are handled as in SetWeek.
+func (s *ScheduleService) SetException(buildingId int, exception domain.ScheduleException, resolution string) (int, []*domain.ScheduleConflict, error) {
+	if exception.IsClosed {
+		exception.OpenTime, exception.CloseTime = nil, nil
+	} else {
+		if exception.OpenTime == nil || exception.CloseTime == nil {
+			return 0, nil, fmt.Errorf("service.SetException: %w", domain.ErrInvalidSchedule)
+		}
+
+		if _, _, err := parseHours(*exception.OpenTime, *exception.CloseTime); err != nil {
+			return 0, nil, fmt.Errorf("service.SetException: %w", err)
+		}
+	}
+
+	var conflicts []*domain.ScheduleConflict
+
+	resolve := conflictResolver(buildingId, resolution, &conflicts)
+
+	id, err := s.repos.SetException(buildingId, exception, func(schedule repository.ScheduleReader) ([]int, error) {
+		if err := checkExceptionOvernight(schedule, buildingId, exception.Date); err != nil {
+			return nil, err
+		}
+		return resolve(schedule)
+	})
+	if err != nil {
+		return 0, conflicts, fmt.Errorf("service.SetException: %w", err)
+	}
+
+	s.notifyResolved(conflicts, resolution)
+
+	return id, conflicts, nil
+}
+
+// findConflicts checks the upcoming orders against the schedule the reader sees.
+func findConflicts(schedule repository.ScheduleReader, buildingId int) ([]*domain.ScheduleConflict, error) {
+	timezone, err := schedule.GetTimezone(buildingId)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := domain.Location(timezone)
+
+	now := time.Now().In(loc)
+
+	current, err := schedule.GetWeek(buildingId)
+	if err != nil {
+		return nil, err
+	}
+
+	week := make(map[int]domain.BuildingHours, 7)
+
+	for _, day := range current {
+		week[day.Weekday] = *day
+	}
+
+	// an order of yesterday may still be going on after midnight
+	currentExceptions, err := schedule.GetExceptions(buildingId, now.AddDate(0, 0, -1).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	exceptions := make(map[string]domain.ScheduleException, len(currentExceptions))
+
+	for _, val := range currentExceptions {
+		exceptions[val.Date] = *val
+	}
+
+	orders, err := schedule.GetUpcomingOrders(buildingId, now.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]*domain.ScheduleConflict, 0)
+
+	for _, order := range orders {
+		date := time.Unix(int64(order.OrderDate), 0).UTC()
+		start := localMinutes(date, time.Unix(int64(order.StartDate), 0), loc)
+		end := localMinutes(date, time.Unix(int64(order.EndDate), 0), loc)
+
+		order.StartAt = time.Unix(int64(order.StartDate), 0).In(loc).Format(time.RFC3339)
+		order.EndAt = time.Unix(int64(order.EndDate), 0).In(loc).Format(time.RFC3339)
+
+		hours := hoursOn(date, week, exceptions)
+
+		if !hours.IsClosed && start >= hours.Open && end <= hours.Close {
+			continue
+		}
+
+		conflicts = append(conflicts, order)
+	}
+
+	return conflicts, nil
+}
+
+// resolveConflicts returns the orders to cancel, a change with conflicts and no resolution is refused.
+func resolveConflicts(conflicts []*domain.ScheduleConflict, resolution string) ([]int, error) {
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+
+	switch resolution {
+	case domain.ScheduleKeep:
+		return nil, nil
+	case domain.ScheduleCancel:
+		cancel := make([]int, 0, len(conflicts))
+
+		for _, conflict := range conflicts {
+			cancel = append(cancel, conflict.OrderId)
+		}
+
+		return cancel, nil
+	}
+
+	return nil, domain.ErrScheduleConflict
+}
+
+// notifyResolved tells the customers of the conflicting orders the change has cancelled.
+func (s *ScheduleService) notifyResolved(conflicts []*domain.ScheduleConflict, resolution string) {
+	if resolution == domain.ScheduleCancel && len(conflicts) != 0 {
+		s.notifyCancelled(conflicts)
+	}
+}
+
+// notifyCancelled does not fail the change, the orders are already cancelled.
+func (s *ScheduleService) notifyCancelled(conflicts []*domain.ScheduleConflict) {
+	for _, conflict := range conflicts {
+		// the offset is kept, so the time is the local one of the building
+		start, err := time.Parse(time.RFC3339, conflict.StartAt)
+		if err != nil {
+			logger.Error(fmt.Errorf("service.notifyCancelled: %w", err))
+			continue
+		}
+
+		message, err := s.smsTemplates.OrderCancelled("", conflict.BuildingName, start.Format("02.01.2006"), start.Format("15:04"))
+		if err != nil {
+			logger.Error(fmt.Errorf("service.notifyCancelled: %w", err))
+			continue
+		}
+
+		if err = s.smsSender.Send(conflict.PhoneNumber, message); err != nil {
+			logger.Error(fmt.Errorf("service.notifyCancelled: %w", err))
+		}
+	}
+}
+
+// hoursOn returns the hours of the date from the weekly hours and the exceptions,
+// a day without hours is closed.
+func hoursOn(date time.Time, week map[int]domain.BuildingHours, exceptions map[string]domain.ScheduleException) domain.DayHours {
+	if exception, ok := exceptions[date.Format("2006-01-02")]; ok {
+		if exception.IsClosed || exception.OpenTime == nil || exception.CloseTime == nil {
+			return domain.DayHours{IsClosed: true}
+		}
+
+		open, closeTime, err := parseHours(*exception.OpenTime, *exception.CloseTime)
+		if err != nil {
+			return domain.DayHours{IsClosed: true}
+		}
+
+		return domain.DayHours{Open: open, Close: closeTime}
+	}
+
+	weekday := int(date.Weekday())
+
+	if weekday == 0 {
+		weekday = 7
+	}
+
+	day, ok := week[weekday]
+	if !ok || day.IsClosed {
+		return domain.DayHours{IsClosed: true}
+	}
+
+	open, closeTime, err := parseHours(day.OpenTime, day.CloseTime)
+	if err != nil {
+		return domain.DayHours{IsClosed: true}
+	}
+
+	return domain.DayHours{Open: open, Close: closeTime}
+}
+
+func (s *ScheduleService) DeleteException(buildingId, id int) error {
+	if err := s.repos.DeleteException(buildingId, id); err != nil {
+		return fmt.Errorf("service.DeleteException: %w", err)
+	}
+	return nil
+}
+
+// GetDayHours returns the hours the building works on the date.
+func (s *ScheduleService) GetDayHours(buildingId int, date time.Time) (*domain.DayHours, error) {
+	weekday := int(date.Weekday())
+
+	if weekday == 0 {
+		weekday = 7
+	}
+
+	hours, err := s.repos.GetDayHours(buildingId, date.Format("2006-01-02"), weekday)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetDayHours: %w", err)
+	}
+
+	if hours.IsClosed {
+		return &domain.DayHours{IsClosed: true}, nil
+	}
+
+	open, closeTime, err := parseHours(hours.OpenTime, hours.CloseTime)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetDayHours: %w", err)
+	}
+
+	return &domain.DayHours{Open: open, Close: closeTime}, nil
+}
+
+// businessDate returns the business day as UTC midnight of its date, it is taken from date
+// as "2006-01-02", from the local date of the unix seconds or is today in the location.
+func businessDate(date string, unix float64, loc *time.Location) (time.Time, error) {
+	if date != "" {
+		return time.Parse("2006-01-02", date)
+	}
+
+	t := time.Now().In(loc)
+
+	if unix != 0 {
+		t = time.Unix(int64(unix), 0).In(loc)
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// localTime returns the moment the wall clock of the location shows minutes after the
+// midnight of the date, minutes past the end of the day are on the next one. The wall
+// clock is used instead of adding durations, so a DST change does not shift the slots.
+// A time the clock skips is moved to the end of the gap, the slots inside it become empty.
+func localTime(date time.Time, minutes int, loc *time.Location) time.Time {
+	t := time.Date(date.Year(), date.Month(), date.Day(), 0, minutes, 0, 0, loc)
+
+	if localMinutes(date, t, loc) == minutes {
+		return t
+	}
+
+	// no offset is a day long, so the wall clock is before the time a day earlier and after it a day later
+	wall := time.Date(date.Year(), date.Month(), date.Day(), 0, minutes, 0, 0, time.UTC)
+	from, to := wall.Add(-24*time.Hour), wall.Add(24*time.Hour)
+
+	for to.Sub(from) > time.Minute {
+		mid := from.Add(to.Sub(from) / 2).Truncate(time.Minute)
+
+		if localMinutes(date, mid, loc) >= minutes {
+			to = mid
+		} else {
+			from = mid
+		}
+	}
+
+	return to.In(loc)
+}
+
+// localMinutes is the reverse of localTime, it counts the minutes by the wall clock of the
+// location from the midnight of the date.
+func localMinutes(date, t time.Time, loc *time.Location) int {
+	t = t.In(loc)
+
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(day.Sub(date).Hours())/24*24*60 + t.Hour()*60 + t.Minute()
+}
+
+// daySlots returns the starts of the slots that fit into the hours, in minutes since midnight.
+func daySlots(hours domain.DayHours, slotLength int) []int {
+	slots := make([]int, 0)
+
+	if hours.IsClosed {
+		return slots
+	}
+
+	for start := hours.Open; start+slotLength <= hours.Close; start += slotLength {
+		slots = append(slots, start)
+	}
+
+	return slots
+}
+
+// parseHours returns the hours in minutes since midnight, a close time not after the open
+// time is moved to the next day.
+func parseHours(open, closeTime string) (int, int, error) {
+	from, err := parseClock(open)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err := parseClock(closeTime)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if from == to || from == 24*60 {
+		return 0, 0, domain.ErrInvalidSchedule
+	}
+
+	if to < from {
+		to += 24 * 60
+	}
+
+	return from, to, nil
+}
+
+// parseClock turns "15:04" or "15:04:05" into minutes since midnight, "24:00" is the end of the day.
+func parseClock(value string) (int, error) {
+	parts := strings.Split(value, ":")
+
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, domain.ErrInvalidSchedule
+	}
+
+	hh, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, domain.ErrInvalidSchedule
+	}
+
+	mm, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, domain.ErrInvalidSchedule
+	}
+
+	if hh < 0 || mm < 0 || mm > 59 || hh*60+mm > 24*60 {
+		return 0, domain.ErrInvalidSchedule
+	}
+
+	return hh*60 + mm, nil
+}
+
+// formatClock prints the time of day, minutes past the end of the day are on the next one.
+func formatClock(minutes int) string {
+	minutes %= 24 * 60
+
+	return fmt.Sprintf("%02d:%02d:00", minutes/60, minutes%60)
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestLocalTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		date     string
+		minutes  int
+		want     string
+	}{
+		{"berlin before spring forward", "Europe/Berlin", "2024-03-31", 60, "2024-03-31T00:00:00Z"},
+		{"berlin inside the spring gap moves to its end", "Europe/Berlin", "2024-03-31", 150, "2024-03-31T01:00:00Z"},
+		{"berlin end of the spring gap", "Europe/Berlin", "2024-03-31", 180, "2024-03-31T01:00:00Z"},
+		{"berlin after spring forward", "Europe/Berlin", "2024-03-31", 600, "2024-03-31T08:00:00Z"},
+		{"berlin after fall back", "Europe/Berlin", "2024-10-27", 240, "2024-10-27T03:00:00Z"},
+		{"berlin after midnight into the fall back day", "Europe/Berlin", "2024-10-26", 24*60 + 60, "2024-10-26T23:00:00Z"},
+		{"almaty before the switch to +05", "Asia/Almaty", "2024-02-29", 600, "2024-02-29T04:00:00Z"},
+		{"almaty after the switch to +05", "Asia/Almaty", "2024-03-01", 600, "2024-03-01T05:00:00Z"},
+		{"almaty overnight across the switch", "Asia/Almaty", "2024-02-29", 24*60 + 60, "2024-02-29T20:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := mustLocation(t, tt.location)
+			date, _ := time.Parse("2006-01-02", tt.date)
+
+			got := localTime(date, tt.minutes, loc)
+
+			want, _ := time.Parse(time.RFC3339, tt.want)
+
+			if !got.Equal(want) {
+				t.Errorf("localTime(%s, %d) = %s, want %s", tt.date, tt.minutes, got.UTC().Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalMinutes(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		date     string
+		instant  string
+		want     int
+	}{
+		{"berlin spring forward", "Europe/Berlin", "2024-03-31", "2024-03-31T01:00:00Z", 180},
+		{"berlin fall back first 02:30", "Europe/Berlin", "2024-10-27", "2024-10-27T00:30:00Z", 150},
+		{"berlin fall back second 02:30", "Europe/Berlin", "2024-10-27", "2024-10-27T01:30:00Z", 150},
+		{"berlin after midnight of the date", "Europe/Berlin", "2024-10-26", "2024-10-26T23:00:00Z", 24*60 + 60},
+		{"almaty before the date", "Asia/Almaty", "2024-03-01", "2024-02-29T17:00:00Z", -60},
+		{"almaty after the switch to +05", "Asia/Almaty", "2024-03-01", "2024-03-01T05:00:00Z", 600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := mustLocation(t, tt.location)
+			date, _ := time.Parse("2006-01-02", tt.date)
+			instant, _ := time.Parse(time.RFC3339, tt.instant)
+
+			if got := localMinutes(date, instant, loc); got != tt.want {
+				t.Errorf("localMinutes(%s, %s) = %d, want %d", tt.date, tt.instant, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLocalTimeRoundTrip walks every slot of the days around the DST changes, each time the
+// clock shows once has to come back as the same minutes.
+func TestLocalTimeRoundTrip(t *testing.T) {
+	tests := []struct {
+		location string
+		date     string
+	}{
+		{"Europe/Berlin", "2024-03-31"},
+		{"Europe/Berlin", "2024-10-27"},
+		{"Asia/Almaty", "2024-02-29"},
+		{"Asia/Almaty", "2024-03-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.location+" "+tt.date, func(t *testing.T) {
+			loc := mustLocation(t, tt.location)
+			date, _ := time.Parse("2006-01-02", tt.date)
+
+			for minutes := 0; minutes < 2*24*60; minutes += 30 {
+				got := localMinutes(date, localTime(date, minutes, loc), loc)
+
+				// only the times skipped by the clock move, and only forward
+				if got < minutes {
+					t.Errorf("localMinutes(localTime(%d)) = %d", minutes, got)
+				}
+			}
+		})
+	}
+}
+
+func TestBusinessDate(t *testing.T) {
+	almaty := mustLocation(t, "Asia/Almaty")
+	berlin := mustLocation(t, "Europe/Berlin")
+
+	evening := float64(time.Date(2024, 5, 19, 20, 0, 0, 0, time.UTC).Unix())
+
+	tests := []struct {
+		name    string
+		date    string
+		unix    float64
+		loc     *time.Location
+		want    string
+		wantErr bool
+	}{
+		{"date wins over unix", "2024-05-20", evening, berlin, "2024-05-20", false},
+		{"unix already the next day in almaty", "", evening, almaty, "2024-05-20", false},
+		{"unix still the same day in berlin", "", evening, berlin, "2024-05-19", false},
+		{"malformed date", "20.05.2024", 0, almaty, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := businessDate(tt.date, tt.unix, tt.loc)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("businessDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got.Location() != time.UTC || got.Hour() != 0 || got.Minute() != 0 {
+				t.Errorf("businessDate() = %s, want UTC midnight", got)
+			}
+
+			if got.Format("2006-01-02") != tt.want {
+				t.Errorf("businessDate() = %s, want %s", got.Format("2006-01-02"), tt.want)
+			}
+		})
+	}
+}
+
+func TestDaySlots(t *testing.T) {
+	tests := []struct {
+		name       string
+		hours      domain.DayHours
+		slotLength int
+		want       []int
+	}{
+		{"overnight", domain.DayHours{Open: 22 * 60, Close: 26 * 60}, 60, []int{1320, 1380, 1440, 1500}},
+		{"overnight with half hour slots", domain.DayHours{Open: 23 * 60, Close: 25 * 60}, 30, []int{1380, 1410, 1440, 1470}},
+		{"till midnight", domain.DayHours{Open: 22 * 60, Close: 24 * 60}, 90, []int{1320}},
+		{"slot that does not fit is left out", domain.DayHours{Open: 600, Close: 700}, 60, []int{600}},
+		{"closed", domain.DayHours{IsClosed: true, Open: 600, Close: 1200}, 30, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := daySlots(tt.hours, tt.slotLength); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("daySlots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunsIntoNext(t *testing.T) {
+	tests := []struct {
+		name string
+		day  domain.DayHours
+		next domain.DayHours
+		want bool
+	}{
+		{"overnight into the opening", domain.DayHours{Open: 22 * 60, Close: 27 * 60}, domain.DayHours{Open: 2 * 60, Close: 12 * 60}, true},
+		{"overnight till the opening", domain.DayHours{Open: 22 * 60, Close: 27 * 60}, domain.DayHours{Open: 3 * 60, Close: 12 * 60}, false},
+		{"till midnight", domain.DayHours{Open: 8 * 60, Close: 24 * 60}, domain.DayHours{Open: 0, Close: 12 * 60}, false},
+		{"next day closed", domain.DayHours{Open: 22 * 60, Close: 27 * 60}, domain.DayHours{IsClosed: true}, false},
+		{"day closed", domain.DayHours{IsClosed: true, Open: 22 * 60, Close: 27 * 60}, domain.DayHours{Open: 0, Close: 12 * 60}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runsIntoNext(tt.day, tt.next); got != tt.want {
+				t.Errorf("runsIntoNext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package service
 
 import (
+	"carWash/internal/config"
 	"carWash/internal/domain"
 	"carWash/internal/repository"
 	"carWash/pkg/auth"
+	"carWash/pkg/email"
 	"carWash/pkg/hash"
 	"carWash/pkg/phone"
+	"carWash/pkg/sms"
+	"carWash/pkg/totp"
 	"context"
 	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/fiber/v2"
@@ -18,11 +22,18 @@ type SignUpInput struct {
 	Password        string
 	ConfirmPassword string
 	UserType        string
+	Language        string
+	Business        *domain.ManagerBusiness
 }
 
+// Tokens carries only TwoFactorChallenge when the password was right
+// but the sign-in still has to be confirmed with a second factor.
+// UserId is not sent to the client, it is kept for the audit log.
 type Tokens struct {
-	AccessToken  string
-	RefreshToken string
+	UserId             int
+	AccessToken        string
+	RefreshToken       string
+	TwoFactorChallenge string
 }
 
 type FavouriteInput struct {
@@ -33,29 +44,59 @@ type FavouriteInput struct {
 type UserAuth interface {
 	VerifyExistenceUser(phone string) error
 	UserSignUp(input SignUpInput) (string, error)
-	Verify(input domain.VerifyUserInput) error
+	Verify(input domain.VerifyUserInput, ip string) error
 
-	UserSignIn(user domain.User) (*Tokens, error)
+	UserSignIn(user domain.User, device domain.Device) (*Tokens, error)
+	RefreshTokens(refreshToken string, device domain.Device) (*Tokens, error)
+
+	GetSessions(userId, currentSessionId int) ([]*domain.Session, error)
+	RevokeSession(id, userId int) error
+	RevokeAllSessions(userId int) error
+	IsSessionRevoked(sessionId int) (bool, error)
 
 	SetPassword(id int, input domain.SetPasswordInput) error
 
-	ResetPassword(phone string) (string, error)
-	VerifyPhoneNumber(input domain.VerifyPhoneNumberInput) error
+	ResetPassword(phone, language string) (string, error)
+	VerifyPhoneNumber(input domain.VerifyPhoneNumberInput, ip string) error
 	ResetPasswordConfirm(input domain.ResetPasswordInput) error
 
-	UpdatePhoneNumberVerify(inp domain.User) (string, error)
-	UpdatePhoneNumberConfirm(input domain.ResetPhoneNumberInput, id int) error
+	UpdatePhoneNumberVerify(inp domain.User, language string) (string, error)
+	UpdatePhoneNumberConfirm(input domain.ResetPhoneNumberInput, id int, ip string) error
 
 	GetUserInfo(id int) (*domain.User, error)
 	UpdateUserInfo(user domain.UserUpdate, id int) error
+
+	AttachEmail(userId int, email string) error
+	VerifyEmail(token string) error
+
+	GetManagerProfile(userId int) (*domain.ManagerProfile, error)
+
+	GuestSendCode(phone, language string) (string, error)
+	GuestVerify(input domain.GuestVerifyInput, device domain.Device, ip string) (*Tokens, error)
+	ClaimGuest(userId, sessionId int, input domain.ClaimGuestInput, device domain.Device) (*Tokens, error)
+
+	DeleteAccount(userId int, password string) error
+}
+
+type TwoFactor interface {
+	GetTwoFactorStatus(userId int, userType string) (*domain.TwoFactorStatus, error)
+	IsTwoFactorEnabled(userId int) (bool, error)
+	EnrollTwoFactor(userId int) (*domain.TwoFactorEnrollment, error)
+	EnableTwoFactor(userId int, code string) (*domain.RecoveryCodes, error)
+	DisableTwoFactor(userId int, userType, code string) error
+	RegenerateRecoveryCodes(userId int, code string) (*domain.RecoveryCodes, error)
+	ResetTwoFactor(userId int) error
+	TwoFactorSignIn(input domain.TwoFactorSignInInput) (*Tokens, error)
 }
 
 type Building interface {
 	Create(c *fiber.Ctx, building domain.Building) (int, error)
 	GetAll(c *fiber.Ctx, page domain.Pagination, info domain.UserInfo, building domain.FilterForBuilding) (*domain.GetAllResponses, error)
 	GetById(c *fiber.Ctx, info domain.UserInfo, id int) (*domain.Building, error)
-	Update(c *fiber.Ctx, id int, inp domain.Building) error
+	Update(c *fiber.Ctx, id int, inp domain.Building, resolution string) ([]*domain.ScheduleConflict, error)
 	Delete(c *fiber.Ctx, id int) error
+	GetMap(c *fiber.Ctx, filter domain.MapFilter) (*domain.MapResponse, error)
+	Suggest(c *fiber.Ctx, filter domain.SuggestFilter) ([]*domain.BuildingSuggestion, error)
 }
 
 type Pitch interface {
@@ -75,6 +116,7 @@ type Favourite interface {
 
 type Order interface {
 	Create(ctx *fiber.Ctx, order domain.Order) (int, error)
+	Block(ctx *fiber.Ctx, order domain.Order) (int, error)
 	GetAll(ctx *fiber.Ctx, page domain.Pagination, info domain.UserInfo, order domain.FilterForOrder) (*domain.GetAllResponses, error)
 	GetAllBookTime(ctx *fiber.Ctx, times domain.FilterForOrderTimes) (*domain.GetAllResponses, error)
 	Delete(ctx *fiber.Ctx, id int) error
@@ -109,8 +151,70 @@ type Card interface {
 	Delete(ctx *fiber.Ctx, id, userId int) error
 }
 
+type Admin interface {
+	GetAllUsers(ctx *fiber.Ctx, page domain.Pagination, filter domain.FilterForUser) (*domain.GetAllResponses, error)
+	GetUserById(ctx *fiber.Ctx, id int) (*domain.AdminUser, error)
+	SetRole(ctx *fiber.Ctx, adminId, id int, userType string) error
+	Ban(ctx *fiber.Ctx, adminId, id int, reason string) error
+	Unban(ctx *fiber.Ctx, id int) error
+	GetUserOrders(ctx *fiber.Ctx, page domain.Pagination, userId int) (*domain.GetAllResponses, error)
+	GetUserComments(ctx *fiber.Ctx, page domain.Pagination, userId int) (*domain.GetAllResponses, error)
+	GetUserFeedbacks(ctx *fiber.Ctx, page domain.Pagination, userId int) (*domain.GetAllResponses, error)
+
+	GetAllManagers(ctx *fiber.Ctx, page domain.Pagination, filter domain.FilterForManager) (*domain.GetAllResponses, error)
+	ApproveManager(ctx *fiber.Ctx, adminId, userId int) error
+	RejectManager(ctx *fiber.Ctx, adminId, userId int, reason string) error
+
+	ResetTwoFactor(ctx *fiber.Ctx, adminId, userId int) error
+}
+
+type Access interface {
+	HasPermission(userType string, userId, buildingId int, permission string) (bool, error)
+	GetPitchBuilding(pitchId int) (int, error)
+
+	GetRoles() ([]*domain.Role, error)
+	GetStaff(buildingId int) ([]*domain.Staff, error)
+	AddStaff(buildingId int, input domain.StaffInput) (int, error)
+	RemoveStaff(buildingId, userId int) error
+}
+
+type Audit interface {
+	Record(entry domain.AuditEntry)
+	GetAll(ctx *fiber.Ctx, page domain.Pagination, filter domain.FilterForAudit) (*domain.GetAllResponses, error)
+	Purge() error
+	Watch(ctx context.Context, interval time.Duration)
+}
+
+type APIKey interface {
+	Create(userId int, userType string, input domain.APIKeyInput) (*domain.CreatedAPIKey, error)
+	GetAll(userId int) ([]*domain.APIKey, error)
+	Revoke(id, userId int) error
+	Authenticate(key, ip string) (*domain.APIKey, error)
+}
+
+type Gallery interface {
+	AddImages(c *fiber.Ctx, owner domain.ImageOwner, images []string) ([]*domain.Image, error)
+	SetImagesOrder(c *fiber.Ctx, owner domain.ImageOwner, ids []int) error
+	SetCover(c *fiber.Ctx, owner domain.ImageOwner, imageId int) error
+	DeleteImage(c *fiber.Ctx, owner domain.ImageOwner, imageId int) error
+}
+
+type Schedule interface {
+	GetSchedule(buildingId int) (*domain.BuildingSchedule, error)
+	SetWeek(buildingId int, days []domain.BuildingHours, resolution string) ([]*domain.ScheduleConflict, error)
+	SetException(buildingId int, exception domain.ScheduleException, resolution string) (int, []*domain.ScheduleConflict, error)
+	DeleteException(buildingId, id int) error
+	SetBookingRules(buildingId int, rules domain.BookingRules) error
+	SetPitchBookingRules(pitchId int, rules domain.PitchBookingRulesInput) error
+}
+
+type Account interface {
+	Export(ctx *fiber.Ctx, userId int) (*domain.AccountExport, error)
+}
+
 type Service struct {
 	UserAuth
+	TwoFactor
 	Building
 	Pitch
 	Favourite
@@ -119,6 +223,13 @@ type Service struct {
 	Feedback
 	FootService
 	Card
+	Admin
+	Access
+	Audit
+	APIKey
+	Gallery
+	Schedule
+	Account
 }
 
 type Deps struct {
@@ -130,18 +241,40 @@ type Deps struct {
 	TokenManager    auth.TokenManager
 	AccessTokenTTL  time.Duration
 	RefreshTokenTTL time.Duration
+	GuestTokenTTL   time.Duration
+	SMSSender       sms.Sender
+	SMSTemplates    *sms.Templates
+	OTP             OTPPolicy
+	EmailSender     email.Sender
+	Email           config.EmailConfig
+	TOTP            *totp.Generator
+	TwoFactor       TwoFactorPolicy
+	AuditRetention  time.Duration
 }
 
 func NewService(deps Deps) *Service {
+	emails := NewEmailService(deps.EmailSender, deps.Email)
+	access := NewAccessService(deps.Repos.Access, deps.Repos.UserAuth)
+	schedule := NewScheduleService(deps.Repos.Schedule, deps.SMSSender, deps.SMSTemplates)
+	userAuth := NewUserAuthService(deps.Repos.UserAuth, deps.Hashes, deps.OtpPhone, deps.Redis, deps.Ctx, deps.TokenManager, deps.AccessTokenTTL, deps.RefreshTokenTTL, deps.GuestTokenTTL, deps.SMSSender, deps.SMSTemplates, deps.OTP, emails, deps.TOTP, deps.TwoFactor)
+
 	return &Service{
-		UserAuth:    NewUserAuthService(deps.Repos.UserAuth, deps.Hashes, deps.OtpPhone, deps.Redis, deps.Ctx, deps.TokenManager, deps.AccessTokenTTL, deps.RefreshTokenTTL),
-		Building:    NewBuildingService(deps.Repos.Building),
+		UserAuth:    userAuth,
+		TwoFactor:   userAuth,
+		Building:    NewBuildingService(deps.Repos.Building, schedule),
 		Pitch:       NewPitchService(deps.Repos.Pitch),
 		Favourite:   NewFavouriteService(deps.Repos.Favourite),
-		Order:       NewOrderService(deps.Repos.Order),
+		Order:       NewOrderService(deps.Repos.Order, deps.Repos.UserAuth, schedule, emails),
 		Comment:     NewCommentService(deps.Repos.Comment),
 		Feedback:    NewFeedbackService(deps.Repos.Feedback),
 		FootService: NewFootServiceService(deps.Repos.FootService),
 		Card:        NewCardService(deps.Repos.Card),
+		Admin:       NewAdminService(deps.Repos.Admin, deps.Repos.Order, userAuth, userAuth, deps.SMSSender, deps.SMSTemplates),
+		Access:      access,
+		Audit:       NewAuditService(deps.Repos.Audit, deps.AuditRetention),
+		APIKey:      NewAPIKeyService(deps.Repos.APIKey, access),
+		Gallery:     NewGalleryService(deps.Repos.Gallery),
+		Schedule:    schedule,
+		Account:     NewAccountService(deps.Repos.UserAuth, deps.Repos.Order, deps.Repos.Card, deps.Repos.Favourite, deps.Repos.Admin),
 	}
 }
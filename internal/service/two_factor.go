@@ -0,0 +1,372 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"carWash/pkg/totp"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"strings"
+	"time"
+)
+
+const recoveryCodesCount = 10
+
+type TwoFactorPolicy struct {
+	ChallengeTTL time.Duration
+}
+
+// twoFactorChallenge is what the first sign-in step leaves for the second one.
+type twoFactorChallenge struct {
+	UserId   int           `json:"user_id"`
+	UserType string        `json:"user_type"`
+	Device   domain.Device `json:"device"`
+}
+
+// twoFactorRequired reports whether the account type has to use 2FA.
+func twoFactorRequired(userType string) bool {
+	return userType == "admin"
+}
+
+func (u *UserAuthService) GetTwoFactorStatus(userId int, userType string) (*domain.TwoFactorStatus, error) {
+	enabled, err := u.IsTwoFactorEnabled(userId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetTwoFactorStatus: %w", err)
+	}
+
+	return &domain.TwoFactorStatus{Enabled: enabled, Required: twoFactorRequired(userType)}, nil
+}
+
+func (u *UserAuthService) IsTwoFactorEnabled(userId int) (bool, error) {
+	twoFactor, err := u.repos.GetTwoFactor(userId)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("service.IsTwoFactorEnabled: %w", err)
+	}
+
+	return twoFactor.Enabled, nil
+}
+
+func (u *UserAuthService) EnrollTwoFactor(userId int) (*domain.TwoFactorEnrollment, error) {
+	user, err := u.repos.GetUser(userId)
+	if err != nil {
+		return nil, fmt.Errorf("service.EnrollTwoFactor: %w", err)
+	}
+
+	secret, err := u.totp.NewSecret()
+	if err != nil {
+		return nil, fmt.Errorf("service.EnrollTwoFactor: %w", err)
+	}
+
+	if err = u.repos.SetTwoFactorSecret(userId, secret); err != nil {
+		return nil, fmt.Errorf("service.EnrollTwoFactor: %w", err)
+	}
+
+	return &domain.TwoFactorEnrollment{Secret: secret, URI: u.totp.URI(secret, user.PhoneNumber)}, nil
+}
+
+// EnableTwoFactor confirms the enrollment with the first code from the app,
+// the recovery codes are returned only here and stored hashed.
+func (u *UserAuthService) EnableTwoFactor(userId int, code string) (*domain.RecoveryCodes, error) {
+	twoFactor, err := u.repos.GetTwoFactor(userId)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("service.EnableTwoFactor: %w", domain.ErrTwoFactorNotEnabled)
+		}
+		return nil, fmt.Errorf("service.EnableTwoFactor: %w", err)
+	}
+
+	if twoFactor.Enabled {
+		return nil, fmt.Errorf("service.EnableTwoFactor: %w", domain.ErrTwoFactorAlreadyEnabled)
+	}
+
+	if err = u.verifyTOTP(twoFactor, code); err != nil {
+		return nil, fmt.Errorf("service.EnableTwoFactor: %w", err)
+	}
+
+	codes, hashes, err := u.newRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("service.EnableTwoFactor: %w", err)
+	}
+
+	if err = u.repos.EnableTwoFactor(userId, hashes); err != nil {
+		return nil, fmt.Errorf("service.EnableTwoFactor: %w", err)
+	}
+
+	return &domain.RecoveryCodes{Codes: codes}, nil
+}
+
+func (u *UserAuthService) DisableTwoFactor(userId int, userType, code string) error {
+	if twoFactorRequired(userType) {
+		return fmt.Errorf("service.DisableTwoFactor: %w", domain.ErrTwoFactorRequired)
+	}
+
+	if err := u.verifyUserSecondFactor(userId, code); err != nil {
+		return fmt.Errorf("service.DisableTwoFactor: %w", err)
+	}
+
+	if err := u.repos.DeleteTwoFactor(userId); err != nil {
+		return fmt.Errorf("service.DisableTwoFactor: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserAuthService) RegenerateRecoveryCodes(userId int, code string) (*domain.RecoveryCodes, error) {
+	if err := u.verifyUserSecondFactor(userId, code); err != nil {
+		return nil, fmt.Errorf("service.RegenerateRecoveryCodes: %w", err)
+	}
+
+	codes, hashes, err := u.newRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("service.RegenerateRecoveryCodes: %w", err)
+	}
+
+	if err = u.repos.SetRecoveryCodes(userId, hashes); err != nil {
+		return nil, fmt.Errorf("service.RegenerateRecoveryCodes: %w", err)
+	}
+
+	return &domain.RecoveryCodes{Codes: codes}, nil
+}
+
+// ResetTwoFactor is used by admins when a user has lost both the app and the recovery codes,
+// the account may be compromised so every session of it is ended as well.
+func (u *UserAuthService) ResetTwoFactor(userId int) error {
+	if err := u.repos.DeleteTwoFactor(userId); err != nil {
+		return fmt.Errorf("service.ResetTwoFactor: %w", err)
+	}
+
+	if err := u.RevokeAllSessions(userId); err != nil {
+		return fmt.Errorf("service.ResetTwoFactor: %w", err)
+	}
+
+	return nil
+}
+
+// TwoFactorSignIn is the second sign-in step, tokens are issued only after the code is verified.
+func (u *UserAuthService) TwoFactorSignIn(input domain.TwoFactorSignInInput) (*Tokens, error) {
+	key := twoFactorChallengeKey(input.Challenge)
+
+	value, err := u.redis.Get(u.ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("service.TwoFactorSignIn: %w", domain.ErrInvalidTwoFactorChallenge)
+		}
+		return nil, fmt.Errorf("service.TwoFactorSignIn: %w", err)
+	}
+
+	var challenge twoFactorChallenge
+
+	if err = json.Unmarshal([]byte(value), &challenge); err != nil {
+		return nil, fmt.Errorf("service.TwoFactorSignIn: %w", err)
+	}
+
+	if err = u.verifySecondFactor(challenge.UserId, input.Code); err != nil {
+		if errors.Is(err, domain.ErrInvalidTwoFactorCode) {
+			if limitErr := u.registerChallengeFailure(input.Challenge); limitErr != nil {
+				return nil, fmt.Errorf("service.TwoFactorSignIn: %w", limitErr)
+			}
+		}
+		return nil, fmt.Errorf("service.TwoFactorSignIn: %w", err)
+	}
+
+	// the challenge is single use, whoever deletes it first gets the tokens
+	deleted, err := u.redis.Del(u.ctx, key, twoFactorAttemptsKey(input.Challenge)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("service.TwoFactorSignIn: %w", err)
+	}
+
+	if deleted == 0 {
+		return nil, fmt.Errorf("service.TwoFactorSignIn: %w", domain.ErrInvalidTwoFactorChallenge)
+	}
+
+	return u.createSession(challenge.UserId, challenge.UserType, challenge.Device)
+}
+
+func (u *UserAuthService) newTwoFactorChallenge(userId int, userType string, device domain.Device) (string, error) {
+	token, err := u.tokenManager.NewRefreshToken()
+	if err != nil {
+		return "", fmt.Errorf("service.newTwoFactorChallenge: %w", err)
+	}
+
+	value, err := json.Marshal(twoFactorChallenge{UserId: userId, UserType: userType, Device: device})
+	if err != nil {
+		return "", fmt.Errorf("service.newTwoFactorChallenge: %w", err)
+	}
+
+	if err = u.redis.Set(u.ctx, twoFactorChallengeKey(token), value, u.twoFactor.ChallengeTTL).Err(); err != nil {
+		return "", fmt.Errorf("service.newTwoFactorChallenge: %w", err)
+	}
+
+	return token, nil
+}
+
+// registerChallengeFailure drops the challenge after too many wrong codes, the user has to
+// enter the password again.
+func (u *UserAuthService) registerChallengeFailure(challenge string) error {
+	attempts, err := u.redis.Incr(u.ctx, twoFactorAttemptsKey(challenge)).Result()
+	if err != nil {
+		return err
+	}
+
+	if attempts == 1 {
+		if err = u.redis.Expire(u.ctx, twoFactorAttemptsKey(challenge), u.twoFactor.ChallengeTTL).Err(); err != nil {
+			return err
+		}
+	}
+
+	if attempts >= int64(u.otp.MaxAttempts) {
+		if err = u.redis.Del(u.ctx, twoFactorChallengeKey(challenge), twoFactorAttemptsKey(challenge)).Err(); err != nil {
+			return err
+		}
+		return domain.ErrTooManyAttempts
+	}
+
+	return nil
+}
+
+// verifyUserSecondFactor checks the code of a signed in user, the attempt is counted before
+// the code is checked and the user is refused for the challenge TTL once they are used up.
+func (u *UserAuthService) verifyUserSecondFactor(userId int, code string) error {
+	key := twoFactorUserAttemptsKey(userId)
+
+	attempts, err := u.redis.Incr(u.ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	if attempts == 1 {
+		if err = u.redis.Expire(u.ctx, key, u.twoFactor.ChallengeTTL).Err(); err != nil {
+			return err
+		}
+	}
+
+	if attempts > int64(u.otp.MaxAttempts) {
+		ttl, err := u.redis.TTL(u.ctx, key).Result()
+		if err != nil {
+			return err
+		}
+		return &domain.RetryAfterError{Err: domain.ErrTooManyAttempts, RetryAfter: ttl}
+	}
+
+	if err = u.verifySecondFactor(userId, code); err != nil {
+		return err
+	}
+
+	return u.redis.Del(u.ctx, key).Err()
+}
+
+// verifySecondFactor accepts a code from the app or an unused recovery code.
+func (u *UserAuthService) verifySecondFactor(userId int, code string) error {
+	twoFactor, err := u.repos.GetTwoFactor(userId)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrTwoFactorNotEnabled
+		}
+		return err
+	}
+
+	if !twoFactor.Enabled {
+		return domain.ErrTwoFactorNotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+
+	if len(code) == totp.Digits {
+		return u.verifyTOTP(twoFactor, code)
+	}
+
+	return u.useRecoveryCode(userId, code)
+}
+
+// verifyTOTP refuses a code that has already been used within its validity window.
+func (u *UserAuthService) verifyTOTP(twoFactor *domain.TwoFactor, code string) error {
+	counter, ok := u.totp.Validate(twoFactor.Secret, code, time.Now())
+	if !ok {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	fresh, err := u.redis.SetNX(u.ctx, totpUsedKey(twoFactor.UserId, counter), 1, 3*totp.Period).Result()
+	if err != nil {
+		return err
+	}
+
+	if !fresh {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+func (u *UserAuthService) useRecoveryCode(userId int, code string) error {
+	codes, err := u.repos.GetRecoveryCodes(userId)
+	if err != nil {
+		return err
+	}
+
+	code = normalizeRecoveryCode(code)
+
+	for _, recovery := range codes {
+		ok, err := u.hashes.Verify(code, recovery.CodeHash)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			return u.repos.UseRecoveryCode(recovery.Id)
+		}
+	}
+
+	return domain.ErrInvalidTwoFactorCode
+}
+
+func (u *UserAuthService) newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+
+	for i := 0; i < recoveryCodesCount; i++ {
+		b := make([]byte, 5)
+
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		code := hex.EncodeToString(b)
+
+		hash, err := u.hashes.Hash(code)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		codes = append(codes, code[:5]+"-"+code[5:])
+		hashes = append(hashes, hash)
+	}
+
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(code, "-", ""))
+}
+
+func twoFactorChallengeKey(challenge string) string {
+	return fmt.Sprintf("two_factor_challenge:%s", challenge)
+}
+
+func twoFactorAttemptsKey(challenge string) string {
+	return fmt.Sprintf("two_factor_attempts:%s", challenge)
+}
+
+func twoFactorUserAttemptsKey(userId int) string {
+	return fmt.Sprintf("two_factor_user_attempts:%d", userId)
+}
+
+func totpUsedKey(userId int, counter int64) string {
+	return fmt.Sprintf("totp_used:%d:%d", userId, counter)
+}
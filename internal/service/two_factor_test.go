@@ -0,0 +1,156 @@
+package service
+
+import (
+	"carWash/internal/domain"
+	"carWash/internal/repository"
+	"carWash/pkg/totp"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// twoFactorStub serves the 2FA of enabled users, the rest of the repository is not used.
+type twoFactorStub struct {
+	repository.UserAuth
+}
+
+func (s *twoFactorStub) GetTwoFactor(userId int) (*domain.TwoFactor, error) {
+	return &domain.TwoFactor{UserId: userId, Secret: testTOTPSecret, Enabled: true}, nil
+}
+
+// totpCode is the code an authenticator app shows at t.
+func totpCode(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("DecodeString() error = %v", err)
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(at.Unix()/int64(totp.Period.Seconds())))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+
+	return fmt.Sprintf("%06d", binary.BigEndian.Uint32(sum[offset:offset+4])&0x7fffffff%1000000)
+}
+
+// wrongTOTPCode is a code of the right length that is not valid now.
+func wrongTOTPCode(t *testing.T) string {
+	t.Helper()
+
+	now := time.Now()
+
+	for _, code := range []string{"000000", "111111", "222222", "333333"} {
+		if code != totpCode(t, testTOTPSecret, now.Add(-totp.Period)) &&
+			code != totpCode(t, testTOTPSecret, now) &&
+			code != totpCode(t, testTOTPSecret, now.Add(totp.Period)) {
+			return code
+		}
+	}
+
+	t.Fatalf("no wrong code found")
+	return ""
+}
+
+func newTwoFactorService(t *testing.T) *UserAuthService {
+	return &UserAuthService{
+		repos:     &twoFactorStub{},
+		redis:     newFakeRedis(t),
+		ctx:       context.Background(),
+		otp:       OTPPolicy{MaxAttempts: 3},
+		totp:      totp.NewGenerator("carWash", 1),
+		twoFactor: TwoFactorPolicy{ChallengeTTL: 5 * time.Minute},
+	}
+}
+
+func TestVerifyTOTPReplay(t *testing.T) {
+	u := newTwoFactorService(t)
+	code := totpCode(t, testTOTPSecret, time.Now())
+
+	tests := []struct {
+		name    string
+		userId  int
+		code    string
+		wantErr error
+	}{
+		{"fresh code", 1, code, nil},
+		{"same code again", 1, code, domain.ErrInvalidTwoFactorCode},
+		{"same code of another user", 2, code, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			twoFactor := &domain.TwoFactor{UserId: tt.userId, Secret: testTOTPSecret, Enabled: true}
+
+			if err := u.verifyTOTP(twoFactor, tt.code); !errors.Is(err, tt.wantErr) {
+				t.Errorf("verifyTOTP() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyUserSecondFactorAttempts(t *testing.T) {
+	u := newTwoFactorService(t)
+
+	wrong := wrongTOTPCode(t)
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr error
+	}{
+		{"first wrong code", wrong, domain.ErrInvalidTwoFactorCode},
+		{"second wrong code", wrong, domain.ErrInvalidTwoFactorCode},
+		{"last attempt", wrong, domain.ErrInvalidTwoFactorCode},
+		{"attempts used up", totpCode(t, testTOTPSecret, time.Now()), domain.ErrTooManyAttempts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.verifyUserSecondFactor(1, tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("verifyUserSecondFactor() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var retryErr *domain.RetryAfterError
+			if errors.Is(tt.wantErr, domain.ErrTooManyAttempts) && (!errors.As(err, &retryErr) || retryErr.RetryAfter <= 0) {
+				t.Errorf("verifyUserSecondFactor() error = %v, want a RetryAfterError", err)
+			}
+		})
+	}
+}
+
+func TestVerifyUserSecondFactorResets(t *testing.T) {
+	u := newTwoFactorService(t)
+	wrong := wrongTOTPCode(t)
+
+	for i := 0; i < u.otp.MaxAttempts-1; i++ {
+		if err := u.verifyUserSecondFactor(1, wrong); !errors.Is(err, domain.ErrInvalidTwoFactorCode) {
+			t.Fatalf("verifyUserSecondFactor() error = %v, want %v", err, domain.ErrInvalidTwoFactorCode)
+		}
+	}
+
+	if err := u.verifyUserSecondFactor(1, totpCode(t, testTOTPSecret, time.Now())); err != nil {
+		t.Fatalf("verifyUserSecondFactor() error = %v", err)
+	}
+
+	// a success starts the count again
+	for i := 0; i < u.otp.MaxAttempts; i++ {
+		if err := u.verifyUserSecondFactor(1, wrong); !errors.Is(err, domain.ErrInvalidTwoFactorCode) {
+			t.Fatalf("verifyUserSecondFactor() attempt %d error = %v, want the count reset", i+1, err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestNewJWK(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		kty       string
+	}{
+		{AlgorithmRS256, "RSA"},
+		{AlgorithmEdDSA, "OKP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			key, err := generateKey(tt.algorithm)
+			if err != nil {
+				t.Fatalf("generateKey() error = %v", err)
+			}
+
+			jwk, ok := newJWK(key)
+			if !ok {
+				t.Fatalf("newJWK() ok = false")
+			}
+
+			if jwk.Kty != tt.kty || jwk.Kid != key.Id || jwk.Alg != tt.algorithm || jwk.Use != "sig" {
+				t.Errorf("newJWK() = %+v, want kty %s, kid %s and alg %s for signing", jwk, tt.kty, key.Id, tt.algorithm)
+			}
+
+			switch public := key.Public().(type) {
+			case *rsa.PublicKey:
+				n, _ := base64.RawURLEncoding.DecodeString(jwk.N)
+				e, _ := base64.RawURLEncoding.DecodeString(jwk.E)
+
+				if new(big.Int).SetBytes(n).Cmp(public.N) != 0 || new(big.Int).SetBytes(e).Int64() != int64(public.E) {
+					t.Errorf("newJWK() n and e do not match the public key")
+				}
+			case ed25519.PublicKey:
+				x, _ := base64.RawURLEncoding.DecodeString(jwk.X)
+
+				if jwk.Crv != "Ed25519" || !public.Equal(ed25519.PublicKey(x)) {
+					t.Errorf("newJWK() crv %s and x do not match the public key", jwk.Crv)
+				}
+			}
+		})
+	}
+}
+
+func TestManagerJWKS(t *testing.T) {
+	dir := t.TempDir()
+
+	retired := writeTestKey(t, dir, AlgorithmEdDSA, 25*time.Hour)
+	active := writeTestKey(t, dir, AlgorithmEdDSA, 2*time.Hour)
+
+	ring, err := NewKeyRing(dir, AlgorithmEdDSA, testRotation, testLead, testRetention)
+	if err != nil {
+		t.Fatalf("NewKeyRing() error = %v", err)
+	}
+
+	manager, err := NewManager(ring, "")
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	token, err := manager.NewJWT("1", "user", "session", time.Minute)
+	if err != nil {
+		t.Fatalf("NewJWT() error = %v", err)
+	}
+
+	// the retired key stays published until the tokens it signed have expired
+	set := manager.JWKS()
+
+	if len(set.Keys) != 2 || set.Keys[0].Kid != retired.Id || set.Keys[1].Kid != active.Id {
+		t.Fatalf("JWKS() = %+v, want %s and %s", set.Keys, retired.Id, active.Id)
+	}
+
+	if err = ring.Rotate(); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	if len(manager.JWKS().Keys) != 3 {
+		t.Errorf("JWKS() after Rotate() has %d keys, want the upcoming key too", len(manager.JWKS().Keys))
+	}
+
+	userId, userType, err := manager.Parse(token)
+	if err != nil {
+		t.Fatalf("Parse() after Rotate() error = %v", err)
+	}
+
+	if userId != "1" || userType != "user" {
+		t.Errorf("Parse() = %s, %s, want 1, user", userId, userType)
+	}
+}
@@ -0,0 +1,370 @@
+package auth
+
+import (
+	"carWash/pkg/logger"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	rsaKeyBits       = 2048
+	keyFileExt       = ".pem"
+	createdHeader    = "Created"
+	minReloadBackoff = 10 * time.Second
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+type SigningKey struct {
+	Id        string
+	Algorithm string
+	CreatedAt time.Time
+	private   crypto.Signer
+}
+
+func (k *SigningKey) Public() crypto.PublicKey {
+	return k.private.Public()
+}
+
+// KeyRing keeps the signing keys in dir, one PEM file per key named after its kid.
+// A new key is generated ahead and only published for the lead time, so cached key sets
+// know it before it signs anything. The older keys are kept for verification until the
+// tokens they signed have expired, so rotating a key does not log anybody out.
+type KeyRing struct {
+	mu         sync.RWMutex
+	dir        string
+	algorithm  string
+	rotation   time.Duration
+	lead       time.Duration
+	retention  time.Duration
+	keys       []*SigningKey
+	lastLoaded time.Time
+}
+
+// NewKeyRing loads or creates the keys in dir, lead has to cover the key set cache and
+// the time other instances need to load a key another one generated.
+func NewKeyRing(dir, algorithm string, rotation, lead, retention time.Duration) (*KeyRing, error) {
+	if algorithm != AlgorithmRS256 && algorithm != AlgorithmEdDSA {
+		return nil, fmt.Errorf("auth.NewKeyRing: unsupported algorithm %q", algorithm)
+	}
+
+	if rotation <= lead {
+		return nil, fmt.Errorf("auth.NewKeyRing: key rotation %s has to be longer than the publishing lead %s", rotation, lead)
+	}
+
+	k := &KeyRing{dir: dir, algorithm: algorithm, rotation: rotation, lead: lead, retention: retention}
+
+	if err := k.RotateIfDue(); err != nil {
+		return nil, fmt.Errorf("auth.NewKeyRing: %w", err)
+	}
+
+	return k, nil
+}
+
+// Active returns the key new tokens are signed with, the newest key that has been published for the lead time.
+func (k *KeyRing) Active() *SigningKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if len(k.keys) == 0 {
+		return nil
+	}
+
+	for i := len(k.keys) - 1; i >= 0; i-- {
+		if time.Since(k.keys[i].CreatedAt) >= k.lead {
+			return k.keys[i]
+		}
+	}
+
+	// a fresh ring has no tokens out yet, nobody can have cached a key set without its key
+	return k.keys[0]
+}
+
+// Key looks a key up by kid, another instance may have rotated in the meantime
+// so an unknown kid triggers a reload of dir.
+func (k *KeyRing) Key(id string) (*SigningKey, error) {
+	if key := k.find(id); key != nil {
+		return key, nil
+	}
+
+	k.mu.RLock()
+	recent := time.Since(k.lastLoaded) < minReloadBackoff
+	k.mu.RUnlock()
+
+	if !recent {
+		if err := k.Load(); err != nil {
+			return nil, fmt.Errorf("auth.Key: %w", err)
+		}
+
+		if key := k.find(id); key != nil {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("auth.Key: %w", ErrUnknownKey)
+}
+
+func (k *KeyRing) Keys() []*SigningKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return append([]*SigningKey(nil), k.keys...)
+}
+
+func (k *KeyRing) newest() *SigningKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if len(k.keys) == 0 {
+		return nil
+	}
+	return k.keys[len(k.keys)-1]
+}
+
+func (k *KeyRing) find(id string) *SigningKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	for _, key := range k.keys {
+		if key.Id == id {
+			return key
+		}
+	}
+	return nil
+}
+
+// Load reads every key of the configured algorithm from dir, ordered by creation time.
+func (k *KeyRing) Load() error {
+	files, err := filepath.Glob(filepath.Join(k.dir, "*"+keyFileExt))
+	if err != nil {
+		return fmt.Errorf("auth.Load: %w", err)
+	}
+
+	keys := make([]*SigningKey, 0, len(files))
+
+	for _, file := range files {
+		key, err := readKey(file)
+		if err != nil {
+			return fmt.Errorf("auth.Load: %w", err)
+		}
+
+		if key.Algorithm == k.algorithm {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+
+	k.mu.Lock()
+	k.keys = keys
+	k.lastLoaded = time.Now()
+	k.mu.Unlock()
+
+	return nil
+}
+
+// RotateIfDue generates the next key the lead time before the current one has signed for
+// the rotation interval and removes retired keys whose tokens can no longer be valid.
+func (k *KeyRing) RotateIfDue() error {
+	if err := k.Load(); err != nil {
+		return fmt.Errorf("auth.RotateIfDue: %w", err)
+	}
+
+	if newest := k.newest(); newest == nil || time.Since(newest.CreatedAt) >= k.rotation-k.lead {
+		if err := k.Rotate(); err != nil {
+			return fmt.Errorf("auth.RotateIfDue: %w", err)
+		}
+	}
+
+	if err := k.prune(); err != nil {
+		return fmt.Errorf("auth.RotateIfDue: %w", err)
+	}
+
+	return nil
+}
+
+// Watch checks the key ring every interval until ctx is done.
+func (k *KeyRing) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := k.RotateIfDue(); err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+}
+
+func (k *KeyRing) Rotate() error {
+	key, err := generateKey(k.algorithm)
+	if err != nil {
+		return fmt.Errorf("auth.Rotate: %w", err)
+	}
+
+	if err = os.MkdirAll(k.dir, 0700); err != nil {
+		return fmt.Errorf("auth.Rotate: %w", err)
+	}
+
+	if err = writeKey(filepath.Join(k.dir, key.Id+keyFileExt), key); err != nil {
+		return fmt.Errorf("auth.Rotate: %w", err)
+	}
+
+	k.mu.Lock()
+	k.keys = append(k.keys, key)
+	k.mu.Unlock()
+
+	return nil
+}
+
+// prune removes a key once its successor has been signing for longer than the retention,
+// i.e. the longest lifetime of a token it could have issued. The successor signs from the
+// end of its lead time.
+func (k *KeyRing) prune() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	kept := make([]*SigningKey, 0, len(k.keys))
+
+	for i, key := range k.keys {
+		if i < len(k.keys)-1 && time.Since(k.keys[i+1].CreatedAt) > k.lead+k.retention {
+			if err := os.Remove(filepath.Join(k.dir, key.Id+keyFileExt)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("auth.prune: %w", err)
+			}
+			continue
+		}
+		kept = append(kept, key)
+	}
+
+	k.keys = kept
+
+	return nil
+}
+
+func generateKey(algorithm string) (*SigningKey, error) {
+	var (
+		private crypto.Signer
+		err     error
+	)
+
+	switch algorithm {
+	case AlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case AlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		err = fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("auth.generateKey: %w", err)
+	}
+
+	id, err := keyId(private.Public())
+	if err != nil {
+		return nil, fmt.Errorf("auth.generateKey: %w", err)
+	}
+
+	return &SigningKey{Id: id, Algorithm: algorithm, CreatedAt: time.Now().UTC(), private: private}, nil
+}
+
+// keyId derives the kid from the public key, so every instance names a key the same way.
+func keyId(public crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(der)
+
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func writeKey(path string, key *SigningKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key.private)
+	if err != nil {
+		return fmt.Errorf("auth.writeKey: %w", err)
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:    "PRIVATE KEY",
+		Headers: map[string]string{createdHeader: key.CreatedAt.Format(time.RFC3339Nano)},
+		Bytes:   der,
+	})
+
+	// write to a temporary file first so a concurrent Load never sees a half written key
+	tmp := path + ".tmp"
+
+	if err = os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("auth.writeKey: %w", err)
+	}
+
+	if err = os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("auth.writeKey: %w", err)
+	}
+
+	return nil
+}
+
+func readKey(path string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("auth.readKey: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("auth.readKey: %s is not a PEM file", path)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("auth.readKey: %w", err)
+	}
+
+	key := &SigningKey{Id: strings.TrimSuffix(filepath.Base(path), keyFileExt)}
+
+	switch private := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Algorithm, key.private = AlgorithmRS256, private
+	case ed25519.PrivateKey:
+		key.Algorithm, key.private = AlgorithmEdDSA, private
+	default:
+		return nil, fmt.Errorf("auth.readKey: unsupported key type %T in %s", parsed, path)
+	}
+
+	// keys put into dir by hand may have no header, the file time is good enough for them
+	if key.CreatedAt, err = time.Parse(time.RFC3339Nano, block.Headers[createdHeader]); err != nil {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("auth.readKey: %w", err)
+		}
+		key.CreatedAt = info.ModTime().UTC()
+	}
+
+	return key, nil
+}
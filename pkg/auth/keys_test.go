@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	testRotation  = 24 * time.Hour
+	testLead      = time.Hour
+	testRetention = 2 * time.Hour
+)
+
+// writeTestKey puts a key created age ago into dir.
+func writeTestKey(t *testing.T, dir, algorithm string, age time.Duration) *SigningKey {
+	t.Helper()
+
+	key, err := generateKey(algorithm)
+	if err != nil {
+		t.Fatalf("generateKey() error = %v", err)
+	}
+
+	key.CreatedAt = time.Now().Add(-age).UTC()
+
+	if err = writeKey(filepath.Join(dir, key.Id+keyFileExt), key); err != nil {
+		t.Fatalf("writeKey() error = %v", err)
+	}
+
+	return key
+}
+
+func TestKeyRingRotateIfDue(t *testing.T) {
+	tests := []struct {
+		name      string
+		ages      []time.Duration
+		wantKeys  int
+		wantFirst int
+		wantNew   bool
+	}{
+		{"empty dir", nil, 1, -1, true},
+		{"key within its rotation", []time.Duration{time.Hour}, 1, 0, false},
+		{"key due the lead before its rotation ends", []time.Duration{testRotation - testLead + time.Minute}, 2, 0, true},
+		{"predecessor still within the retention", []time.Duration{30 * time.Hour, 2 * time.Hour}, 2, 0, false},
+		{"predecessor past the retention", []time.Duration{30 * time.Hour, 3*time.Hour + time.Minute}, 1, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			written := make([]*SigningKey, 0, len(tt.ages))
+			for _, age := range tt.ages {
+				written = append(written, writeTestKey(t, dir, AlgorithmEdDSA, age))
+			}
+
+			ring, err := NewKeyRing(dir, AlgorithmEdDSA, testRotation, testLead, testRetention)
+			if err != nil {
+				t.Fatalf("NewKeyRing() error = %v", err)
+			}
+
+			keys := ring.Keys()
+			if len(keys) != tt.wantKeys {
+				t.Fatalf("Keys() has %d keys, want %d", len(keys), tt.wantKeys)
+			}
+
+			if tt.wantFirst >= 0 && keys[0].Id != written[tt.wantFirst].Id {
+				t.Errorf("first key = %s, want %s", keys[0].Id, written[tt.wantFirst].Id)
+			}
+
+			generated := len(written) == 0 || keys[len(keys)-1].Id != written[len(written)-1].Id
+			if generated != tt.wantNew {
+				t.Errorf("generated a key = %v, want %v", generated, tt.wantNew)
+			}
+
+			files, err := filepath.Glob(filepath.Join(dir, "*"+keyFileExt))
+			if err != nil {
+				t.Fatalf("Glob() error = %v", err)
+			}
+
+			if len(files) != tt.wantKeys {
+				t.Errorf("dir has %d keys, want %d", len(files), tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestKeyRingActive(t *testing.T) {
+	tests := []struct {
+		name   string
+		ages   []time.Duration
+		active int
+	}{
+		{"fresh ring", []time.Duration{time.Minute}, 0},
+		{"successor within its lead", []time.Duration{23*time.Hour + 30*time.Minute, 30 * time.Minute}, 0},
+		{"successor past its lead", []time.Duration{25 * time.Hour, 2 * time.Hour}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			written := make([]*SigningKey, 0, len(tt.ages))
+			for _, age := range tt.ages {
+				written = append(written, writeTestKey(t, dir, AlgorithmEdDSA, age))
+			}
+
+			ring, err := NewKeyRing(dir, AlgorithmEdDSA, testRotation, testLead, testRetention)
+			if err != nil {
+				t.Fatalf("NewKeyRing() error = %v", err)
+			}
+
+			if got := ring.Active(); got == nil || got.Id != written[tt.active].Id {
+				t.Errorf("Active() = %v, want %s", got, written[tt.active].Id)
+			}
+		})
+	}
+}
+
+func TestKeyRingKeyReload(t *testing.T) {
+	dir := t.TempDir()
+
+	ring, err := NewKeyRing(dir, AlgorithmEdDSA, testRotation, testLead, testRetention)
+	if err != nil {
+		t.Fatalf("NewKeyRing() error = %v", err)
+	}
+
+	// another instance rotates into the same dir
+	other := writeTestKey(t, dir, AlgorithmEdDSA, 0)
+
+	if _, err = ring.Key(other.Id); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("Key() right after a load error = %v, want %v", err, ErrUnknownKey)
+	}
+
+	ring.mu.Lock()
+	ring.lastLoaded = time.Now().Add(-minReloadBackoff)
+	ring.mu.Unlock()
+
+	got, err := ring.Key(other.Id)
+	if err != nil {
+		t.Fatalf("Key() error = %v", err)
+	}
+
+	if got.Id != other.Id || !got.CreatedAt.Equal(other.CreatedAt) {
+		t.Errorf("Key() = %s created %v, want %s created %v", got.Id, got.CreatedAt, other.Id, other.CreatedAt)
+	}
+}
+
+func TestNewKeyRingInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		rotation  time.Duration
+		lead      time.Duration
+	}{
+		{"unsupported algorithm", "HS256", testRotation, testLead},
+		{"lead not shorter than the rotation", AlgorithmEdDSA, testLead, testLead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewKeyRing(t.TempDir(), tt.algorithm, tt.rotation, tt.lead, testRetention); err == nil {
+				t.Errorf("NewKeyRing() error = nil, want an error")
+			}
+		})
+	}
+}
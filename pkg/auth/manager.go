@@ -1,47 +1,69 @@
 package auth
 
 import (
+	"crypto/rand"
 	"fmt"
 	"github.com/golang-jwt/jwt/v4"
-	"math/rand"
 	"time"
 )
 
 type TokenManager interface {
-	NewJWT(userId string, userType string, ttl time.Duration) (string, error)
+	NewJWT(userId string, userType string, sessionId string, ttl time.Duration) (string, error)
 	Parse(accessToken string) (string, string, error)
 	NewRefreshToken() (string, error)
+	Keyfunc(token *jwt.Token) (interface{}, error)
+	JWKS() JWKS
+}
+
+// Claims binds an access token to the session it was issued for,
+// so the token can be rejected as soon as that session is revoked.
+type Claims struct {
+	jwt.StandardClaims
+	SessionId string `json:"sid,omitempty"`
 }
 
 type Manager struct {
-	signingKey string
+	keys      *KeyRing
+	legacyKey []byte
 }
 
-func NewManager(signingKey string) (*Manager, error) {
-	if signingKey == "" {
-		return nil, fmt.Errorf("auth.NewManager: %s", "empty signing key")
+// NewManager signs tokens with the active key of keys. legacySigningKey is the former
+// HS256 secret, when set tokens issued with it are still accepted until they expire.
+func NewManager(keys *KeyRing, legacySigningKey string) (*Manager, error) {
+	if keys == nil {
+		return nil, fmt.Errorf("auth.NewManager: %s", "empty key ring")
+	}
+
+	m := &Manager{keys: keys}
+
+	if legacySigningKey != "" {
+		m.legacyKey = []byte(legacySigningKey)
 	}
 
-	return &Manager{signingKey: signingKey}, nil
+	return m, nil
 }
 
-func (m *Manager) NewJWT(userId string, userType string, ttl time.Duration) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
-		ExpiresAt: time.Now().Add(ttl).Unix(),
-		Id:        userId,
-		Subject:   userType,
+func (m *Manager) NewJWT(userId string, userType string, sessionId string, ttl time.Duration) (string, error) {
+	key := m.keys.Active()
+	if key == nil {
+		return "", fmt.Errorf("auth.NewJWT: %s", "no active signing key")
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), Claims{
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(ttl).Unix(),
+			Id:        userId,
+			Subject:   userType,
+		},
+		SessionId: sessionId,
 	})
-	return token.SignedString([]byte(m.signingKey))
+	token.Header["kid"] = key.Id
+
+	return token.SignedString(key.private)
 }
 
 func (m *Manager) Parse(accessToken string) (string, string, error) {
-	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (i interface{}, err error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-
-		return []byte(m.signingKey), nil
-	})
+	token, err := jwt.Parse(accessToken, m.Keyfunc)
 
 	if err != nil {
 		return "", "", fmt.Errorf("auth.Parse: %w", err)
@@ -54,13 +76,48 @@ func (m *Manager) Parse(accessToken string) (string, string, error) {
 	return claims["jti"].(string), claims["sub"].(string), nil
 }
 
+// Keyfunc picks the verification key by the kid header and checks that
+// the token is signed with the algorithm that key belongs to.
+func (m *Manager) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok && m.legacyKey != nil {
+			return m.legacyKey, nil
+		}
+		return nil, fmt.Errorf("auth.Keyfunc: %s", "missing kid")
+	}
+
+	key, err := m.keys.Key(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.Public(), nil
+}
+
+func (m *Manager) JWKS() JWKS {
+	keys := m.keys.Keys()
+
+	set := JWKS{Keys: make([]JWK, 0, len(keys))}
+
+	for _, key := range keys {
+		if jwk, ok := newJWK(key); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+
+	return set
+}
+
 func (m *Manager) NewRefreshToken() (string, error) {
 	b := make([]byte, 32)
 
-	s := rand.NewSource(time.Now().Unix())
-	r := rand.New(s)
-
-	if _, err := r.Read(b); err != nil {
+	if _, err := rand.Read(b); err != nil {
 		return "", fmt.Errorf("auth.NewRefreshToken: %w", err)
 	}
 
@@ -0,0 +1,51 @@
+package email
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/mail"
+)
+
+type SendInput struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Sender interface {
+	Send(input SendInput) error
+}
+
+func (e *SendInput) GenerateBodyFromHTML(templateFileName string, data interface{}) error {
+	t, err := template.ParseFiles(templateFileName)
+	if err != nil {
+		return fmt.Errorf("email.GenerateBodyFromHTML: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err = t.Execute(buf, data); err != nil {
+		return fmt.Errorf("email.GenerateBodyFromHTML: %w", err)
+	}
+
+	e.Body = buf.String()
+
+	return nil
+}
+
+func (e *SendInput) Validate() error {
+	if e.To == "" {
+		return errors.New("empty to")
+	}
+
+	if e.Subject == "" || e.Body == "" {
+		return errors.New("empty subject/body")
+	}
+
+	if _, err := mail.ParseAddress(e.To); err != nil {
+		return fmt.Errorf("invalid to: %w", err)
+	}
+
+	return nil
+}
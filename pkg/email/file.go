@@ -0,0 +1,40 @@
+package email
+
+import (
+	"carWash/pkg/logger"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileSender is a stand-in for local development and tests,
+// every message is written to its own .eml file in dir.
+type FileSender struct {
+	from string
+	dir  string
+}
+
+func NewFileSender(from, dir string) *FileSender {
+	return &FileSender{from: from, dir: dir}
+}
+
+func (s *FileSender) Send(input SendInput) error {
+	if err := input.Validate(); err != nil {
+		return fmt.Errorf("email.Send: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("email.Send: %w", err)
+	}
+
+	path := filepath.Join(s.dir, fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), input.To))
+
+	if err := os.WriteFile(path, message(s.from, input), 0644); err != nil {
+		return fmt.Errorf("email.Send: %w", err)
+	}
+
+	logger.Infof("email to %s written to %s", input.To, path)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+type SMTPSender struct {
+	from string
+	pass string
+	host string
+	port int
+}
+
+func NewSMTPSender(from, pass, host string, port int) (*SMTPSender, error) {
+	if from == "" {
+		return nil, fmt.Errorf("email.NewSMTPSender: %s", "empty from")
+	}
+
+	return &SMTPSender{from: from, pass: pass, host: host, port: port}, nil
+}
+
+func (s *SMTPSender) Send(input SendInput) error {
+	if err := input.Validate(); err != nil {
+		return fmt.Errorf("email.Send: %w", err)
+	}
+
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	auth := smtp.PlainAuth("", s.from, s.pass, s.host)
+
+	if err := smtp.SendMail(addr, auth, s.from, []string{input.To}, message(s.from, input)); err != nil {
+		return fmt.Errorf("email.Send: %w", err)
+	}
+
+	return nil
+}
+
+// message builds an HTML MIME message, the subject is encoded since it is usually in russian.
+func message(from string, input SendInput) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", input.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", input.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=\"utf-8\"\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(input.Body)
+
+	return buf.Bytes()
+}
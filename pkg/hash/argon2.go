@@ -0,0 +1,104 @@
+package hash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+const (
+	argon2Prefix  = "$argon2id$"
+	argon2Time    = 1
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	argon2KeyLen  = 32
+	argon2SaltLen = 16
+)
+
+var errInvalidArgon2Hash = errors.New("invalid argon2id hash")
+
+// Argon2Hashes hashes passwords with argon2id and a random salt per password.
+// Hashes produced by SHA1Hashes are still accepted by Verify and reported by
+// NeedsRehash, so existing users are migrated on their next sign-in.
+type Argon2Hashes struct {
+	legacy *SHA1Hashes
+}
+
+func NewArgon2Hashes(legacySalt string) *Argon2Hashes {
+	return &Argon2Hashes{legacy: NewSHA1Hashes(legacySalt)}
+}
+
+func (h *Argon2Hashes) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("hash.Hash: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2Prefix,
+		argon2.Version,
+		argon2Memory,
+		argon2Time,
+		argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2Hashes) Verify(password, hashedPassword string) (bool, error) {
+	if !strings.HasPrefix(hashedPassword, argon2Prefix) {
+		return h.legacy.Verify(password, hashedPassword)
+	}
+
+	parts := strings.Split(hashedPassword, "$")
+
+	if len(parts) != 6 {
+		return false, fmt.Errorf("hash.Verify: %w", errInvalidArgon2Hash)
+	}
+
+	var (
+		version            int
+		memory, iterations uint32
+		threads            uint8
+	)
+
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, fmt.Errorf("hash.Verify: %w", errInvalidArgon2Hash)
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &threads); err != nil {
+		return false, fmt.Errorf("hash.Verify: %w", errInvalidArgon2Hash)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, fmt.Errorf("hash.Verify: %w", errInvalidArgon2Hash)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, fmt.Errorf("hash.Verify: %w", errInvalidArgon2Hash)
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, iterations, memory, threads, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(key, otherKey) == 1, nil
+}
+
+// NeedsRehash reports hashes made by SHA1Hashes or with weaker argon2id parameters.
+func (h *Argon2Hashes) NeedsRehash(hashedPassword string) bool {
+	if !strings.HasPrefix(hashedPassword, argon2Prefix) {
+		return true
+	}
+
+	params := fmt.Sprintf("$m=%d,t=%d,p=%d$", argon2Memory, argon2Time, argon2Threads)
+
+	return !strings.Contains(hashedPassword, params)
+}
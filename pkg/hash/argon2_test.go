@@ -0,0 +1,90 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArgon2HashesVerify(t *testing.T) {
+	h := NewArgon2Hashes("salt")
+
+	hashed, err := h.Hash("password")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		hashed   string
+		want     bool
+		wantErr  bool
+	}{
+		{"argon2", "password", hashed, true, false},
+		{"argon2 wrong password", "passwort", hashed, false, false},
+		{"legacy sha1", "password", "73616c745baa61e4c9b93f3f0682250b6cf8331b7ee68fd8", true, false},
+		{"legacy sha1 wrong password", "passwort", "73616c745baa61e4c9b93f3f0682250b6cf8331b7ee68fd8", false, false},
+		{"argon2 missing parts", "password", "$argon2id$v=19$m=65536,t=1,p=4$c2FsdA", false, true},
+		{"argon2 other version", "password", strings.Replace(hashed, "$v=19$", "$v=16$", 1), false, true},
+		{"argon2 broken salt", "password", "$argon2id$v=19$m=65536,t=1,p=4$!!!$c2FsdA", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := h.Verify(tt.password, tt.hashed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgon2HashesSalted(t *testing.T) {
+	h := NewArgon2Hashes("salt")
+
+	first, err := h.Hash("password")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	second, err := h.Hash("password")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	if first == second {
+		t.Errorf("Hash() returned %q twice, want a random salt per password", first)
+	}
+}
+
+func TestArgon2HashesNeedsRehash(t *testing.T) {
+	h := NewArgon2Hashes("salt")
+
+	hashed, err := h.Hash("password")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		hashed string
+		want   bool
+	}{
+		{"current parameters", hashed, false},
+		{"legacy sha1", "73616c745baa61e4c9b93f3f0682250b6cf8331b7ee68fd8", true},
+		{"less memory", strings.Replace(hashed, "$m=65536,", "$m=32768,", 1), true},
+		{"fewer threads", strings.Replace(hashed, ",p=4$", ",p=2$", 1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.NeedsRehash(tt.hashed); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
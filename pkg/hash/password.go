@@ -2,11 +2,14 @@ package hash
 
 import (
 	"crypto/sha1"
+	"crypto/subtle"
 	"fmt"
 )
 
 type PasswordHashes interface {
 	Hash(password string) (string, error)
+	Verify(password, hashedPassword string) (bool, error)
+	NeedsRehash(hashedPassword string) bool
 }
 
 type SHA1Hashes struct {
@@ -26,3 +29,16 @@ func (h *SHA1Hashes) Hash(password string) (string, error) {
 
 	return fmt.Sprintf("%x", hash.Sum([]byte(h.salt))), nil
 }
+
+func (h *SHA1Hashes) Verify(password, hashedPassword string) (bool, error) {
+	hash, err := h.Hash(password)
+	if err != nil {
+		return false, fmt.Errorf("hash.Verify: %w", err)
+	}
+
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(hashedPassword)) == 1, nil
+}
+
+func (h *SHA1Hashes) NeedsRehash(hashedPassword string) bool {
+	return false
+}
@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"math/rand"
+	"time"
+)
+
+// slidingWindow keeps a sorted set of request timestamps per key, drops the ones
+// that left the window and admits the request only while the set is below the limit.
+var slidingWindow = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, 0, now - window)
+
+local count = redis.call('ZCARD', key)
+local allowed = 0
+
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+
+redis.call('PEXPIRE', key, window)
+
+local reset = window
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, count, reset}
+`)
+
+type Policy struct {
+	Limit  int
+	Window time.Duration
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration
+}
+
+type Limiter struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewLimiter(client *redis.Client, prefix string) *Limiter {
+	return &Limiter{client: client, prefix: prefix}
+}
+
+func (l *Limiter) Allow(ctx context.Context, key string, policy Policy) (*Result, error) {
+	now := time.Now()
+
+	values, err := slidingWindow.Run(ctx, l.client, []string{l.prefix + key},
+		now.UnixMilli(),
+		policy.Window.Milliseconds(),
+		policy.Limit,
+		fmt.Sprintf("%d-%d", now.UnixNano(), rand.Int63()),
+	).Int64Slice()
+
+	if err != nil {
+		return nil, fmt.Errorf("ratelimit.Allow: %w", err)
+	}
+
+	return newResult(values, policy), nil
+}
+
+// newResult reads the allowed flag, the count and the reset in milliseconds the script returns.
+func newResult(values []int64, policy Policy) *Result {
+	remaining := policy.Limit - int(values[1])
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &Result{
+		Allowed:   values[0] == 1,
+		Limit:     policy.Limit,
+		Remaining: remaining,
+		Reset:     time.Duration(values[2]) * time.Millisecond,
+	}
+}
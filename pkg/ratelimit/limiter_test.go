@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestNewResult(t *testing.T) {
+	policy := Policy{Limit: 3, Window: time.Minute}
+
+	tests := []struct {
+		name   string
+		values []int64
+		want   *Result
+	}{
+		{"first request", []int64{1, 1, 60000}, &Result{Allowed: true, Limit: 3, Remaining: 2, Reset: time.Minute}},
+		{"last request", []int64{1, 3, 1500}, &Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond}},
+		{"denied", []int64{0, 3, 250}, &Result{Allowed: false, Limit: 3, Remaining: 0, Reset: 250 * time.Millisecond}},
+		{"limit lowered below the count", []int64{0, 5, 250}, &Result{Allowed: false, Limit: 3, Remaining: 0, Reset: 250 * time.Millisecond}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newResult(tt.values, policy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newResult() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLimiterAllow runs the script, so it needs a Redis at RATELIMIT_TEST_REDIS_ADDR.
+func TestLimiterAllow(t *testing.T) {
+	addr := os.Getenv("RATELIMIT_TEST_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("RATELIMIT_TEST_REDIS_ADDR is not set")
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	limiter := NewLimiter(client, fmt.Sprintf("rate_limit_test:%d:", time.Now().UnixNano()))
+	policy := Policy{Limit: 3, Window: 500 * time.Millisecond}
+
+	tests := []struct {
+		name          string
+		key           string
+		wait          time.Duration
+		wantAllowed   bool
+		wantRemaining int
+	}{
+		{"first request", "ip:1", 0, true, 2},
+		{"second request", "ip:1", 0, true, 1},
+		{"last request", "ip:1", 0, true, 0},
+		{"over the limit", "ip:1", 0, false, 0},
+		{"other key", "ip:2", 0, true, 2},
+		{"window passed", "ip:1", policy.Window, true, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			time.Sleep(tt.wait)
+
+			got, err := limiter.Allow(ctx, tt.key, policy)
+			if err != nil {
+				t.Fatalf("Allow() error = %v", err)
+			}
+
+			if got.Allowed != tt.wantAllowed || got.Remaining != tt.wantRemaining {
+				t.Errorf("Allow() = %+v, want allowed %v and %d remaining", got, tt.wantAllowed, tt.wantRemaining)
+			}
+
+			if got.Reset <= 0 || got.Reset > policy.Window {
+				t.Errorf("Reset = %v, want within the window %v", got.Reset, policy.Window)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package sms
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPSender delivers messages through an SMS provider with a JSON HTTP API.
+type HTTPSender struct {
+	url    string
+	from   string
+	apiKey string
+	client *http.Client
+}
+
+type httpMessage struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Text string `json:"text"`
+}
+
+func NewHTTPSender(url, from, apiKey string, timeout time.Duration) *HTTPSender {
+	return &HTTPSender{
+		url:    url,
+		from:   from,
+		apiKey: apiKey,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *HTTPSender) Send(phone, message string) error {
+	body, err := json.Marshal(httpMessage{From: s.from, To: phone, Text: message})
+	if err != nil {
+		return fmt.Errorf("sms.Send: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("sms.Send: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sms.Send: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("sms.Send: provider responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
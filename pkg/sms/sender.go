@@ -0,0 +1,46 @@
+package sms
+
+import (
+	"carWash/pkg/logger"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+type Sender interface {
+	Send(phone, message string) error
+}
+
+// FileSender is a stand-in for local development, messages are appended
+// to a file or written to the log when no file is configured.
+type FileSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSender(path string) *FileSender {
+	return &FileSender{path: path}
+}
+
+func (s *FileSender) Send(phone, message string) error {
+	if s.path == "" {
+		logger.Infof("sms to %s: %s", phone, message)
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("sms.Send: %w", err)
+	}
+	defer file.Close()
+
+	if _, err = fmt.Fprintf(file, "%s\t%s\t%s\n", time.Now().Format(time.RFC3339), phone, message); err != nil {
+		return fmt.Errorf("sms.Send: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package sms
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const (
+	SecretCodeTemplate      = "secret_code"
+	ManagerApprovedTemplate = "manager_approved"
+	ManagerRejectedTemplate = "manager_rejected"
+	OrderCancelledTemplate  = "order_cancelled"
+)
+
+// Templates renders messages in the language preferred by the client.
+type Templates struct {
+	messages        map[string]map[string]*template.Template
+	defaultLanguage string
+}
+
+type secretCodeData struct {
+	Code string
+}
+
+type managerDecisionData struct {
+	Name   string
+	Reason string
+}
+
+type orderCancelledData struct {
+	Building string
+	Date     string
+	Time     string
+}
+
+// NewTemplates parses templates given as name -> language -> text,
+// every template must have a text in the default language.
+func NewTemplates(messages map[string]map[string]string, defaultLanguage string) (*Templates, error) {
+	t := &Templates{
+		messages:        make(map[string]map[string]*template.Template, len(messages)),
+		defaultLanguage: defaultLanguage,
+	}
+
+	for name, texts := range messages {
+		t.messages[name] = make(map[string]*template.Template, len(texts))
+
+		for lang, text := range texts {
+			tmpl, err := template.New(name + "_" + lang).Parse(text)
+			if err != nil {
+				return nil, fmt.Errorf("sms.NewTemplates: %w", err)
+			}
+			t.messages[name][strings.ToLower(lang)] = tmpl
+		}
+
+		if _, ok := t.messages[name][defaultLanguage]; !ok {
+			return nil, fmt.Errorf("sms.NewTemplates: no %s template for default language %q", name, defaultLanguage)
+		}
+	}
+
+	return t, nil
+}
+
+// SecretCode renders the verification code message, acceptLanguage is the raw Accept-Language header.
+func (t *Templates) SecretCode(acceptLanguage, code string) (string, error) {
+	return t.render(SecretCodeTemplate, acceptLanguage, secretCodeData{Code: code})
+}
+
+func (t *Templates) ManagerApproved(acceptLanguage, name string) (string, error) {
+	return t.render(ManagerApprovedTemplate, acceptLanguage, managerDecisionData{Name: name})
+}
+
+func (t *Templates) ManagerRejected(acceptLanguage, name, reason string) (string, error) {
+	return t.render(ManagerRejectedTemplate, acceptLanguage, managerDecisionData{Name: name, Reason: reason})
+}
+
+func (t *Templates) OrderCancelled(acceptLanguage, building, date, time string) (string, error) {
+	return t.render(OrderCancelledTemplate, acceptLanguage, orderCancelledData{Building: building, Date: date, Time: time})
+}
+
+func (t *Templates) render(name, acceptLanguage string, data interface{}) (string, error) {
+	var buf bytes.Buffer
+
+	texts, ok := t.messages[name]
+	if !ok {
+		return "", fmt.Errorf("sms.render: no %s template", name)
+	}
+
+	tmpl := texts[t.language(texts, acceptLanguage)]
+
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("sms.render: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func (t *Templates) language(texts map[string]*template.Template, acceptLanguage string) string {
+	for _, spec := range strings.Split(acceptLanguage, ",") {
+		lang := strings.TrimSpace(spec)
+
+		if i := strings.IndexAny(lang, ";-_"); i != -1 {
+			lang = lang[:i]
+		}
+
+		lang = strings.ToLower(lang)
+
+		if _, ok := texts[lang]; ok {
+			return lang
+		}
+	}
+
+	return t.defaultLanguage
+}
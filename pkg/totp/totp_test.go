@@ -0,0 +1,91 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 seed of the RFC 6238 test vectors, the codes are their last six digits.
+var rfcSecret = encoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestValidateRFC6238(t *testing.T) {
+	g := NewGenerator("carWash", 0)
+
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			counter, ok := g.Validate(rfcSecret, tt.code, time.Unix(tt.unix, 0))
+			if !ok {
+				t.Fatalf("Validate() ok = false, want true")
+			}
+
+			if want := tt.unix / int64(Period.Seconds()); counter != want {
+				t.Errorf("Validate() counter = %d, want %d", counter, want)
+			}
+		})
+	}
+}
+
+func TestValidateWindow(t *testing.T) {
+	// 1111111109 is in the period 37037036, the code 081804 belongs to it
+	period := time.Unix(37037036*int64(Period.Seconds()), 0)
+
+	tests := []struct {
+		name   string
+		skew   int
+		secret string
+		code   string
+		at     time.Time
+		want   bool
+	}{
+		{"current period", 1, rfcSecret, "081804", period, true},
+		{"previous period within the skew", 1, rfcSecret, "081804", period.Add(Period), true},
+		{"next period within the skew", 1, rfcSecret, "081804", period.Add(-Period), true},
+		{"outside the skew", 1, rfcSecret, "081804", period.Add(2 * Period), false},
+		{"no skew", 0, rfcSecret, "081804", period.Add(Period), false},
+		{"lower case secret", 1, strings.ToLower(rfcSecret), "081804", period, true},
+		{"wrong code", 1, rfcSecret, "081805", period, false},
+		{"short code", 1, rfcSecret, "81804", period, false},
+		{"broken secret", 1, "not base32!", "081804", period, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter, ok := NewGenerator("carWash", tt.skew).Validate(tt.secret, tt.code, tt.at)
+			if ok != tt.want {
+				t.Fatalf("Validate() ok = %v, want %v", ok, tt.want)
+			}
+
+			// the counter of the code, not of the time it was sent at, so a replay is recognised
+			if ok && counter != 37037036 {
+				t.Errorf("Validate() counter = %d, want 37037036", counter)
+			}
+		})
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	g := NewGenerator("carWash", 1)
+
+	secret, err := g.NewSecret()
+	if err != nil {
+		t.Fatalf("NewSecret() error = %v", err)
+	}
+
+	key, err := encoding.DecodeString(secret)
+	if err != nil || len(key) != secretSize {
+		t.Errorf("NewSecret() = %q, want %d base32 encoded bytes", secret, secretSize)
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE rotated_tokens;
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS rotated_tokens(
+    id serial not null unique,
+    session_id int references sessions(id) on delete cascade not null,
+    refresh_token varchar(500) not null unique,
+    rotated_at timestamp with time zone default current_timestamp
+);
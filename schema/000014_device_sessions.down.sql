@@ -0,0 +1,6 @@
+ALTER TABLE sessions
+    DROP COLUMN device_name,
+    DROP COLUMN ip,
+    DROP COLUMN user_agent,
+    DROP COLUMN created_at,
+    DROP COLUMN last_used_at;
@@ -0,0 +1,8 @@
+ALTER TABLE sessions
+    ADD COLUMN device_name varchar(255) not null default '',
+    ADD COLUMN ip varchar(100) not null default '',
+    ADD COLUMN user_agent text not null default '',
+    ADD COLUMN created_at timestamp with time zone default current_timestamp,
+    ADD COLUMN last_used_at timestamp with time zone default current_timestamp;
+
+DELETE FROM sessions WHERE refresh_token = '';
@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP COLUMN is_banned,
+    DROP COLUMN ban_reason,
+    DROP COLUMN banned_at;
@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN is_banned boolean not null default false,
+    ADD COLUMN ban_reason text not null default '',
+    ADD COLUMN banned_at timestamp with time zone;
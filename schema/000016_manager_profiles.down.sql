@@ -0,0 +1,3 @@
+DROP TABLE manager_profiles;
+
+DROP type manager_status;
@@ -0,0 +1,19 @@
+CREATE type manager_status AS ENUM ('pending','approved','rejected');
+
+CREATE TABLE IF NOT EXISTS manager_profiles(
+    user_id int references users(id) on delete cascade not null unique,
+    legal_name varchar(255) not null default '',
+    tax_id varchar(50) not null default '',
+    contact_name varchar(255) not null default '',
+    contact_phone varchar(100) not null default '',
+    contact_email varchar(255) not null default '',
+    language varchar(10) not null default '',
+    status manager_status not null default 'pending',
+    reject_reason text not null default '',
+    reviewed_by int references users(id) on delete set null,
+    reviewed_at timestamp with time zone,
+    created_at timestamp with time zone default current_timestamp
+);
+
+INSERT INTO manager_profiles(user_id, status, reviewed_at)
+SELECT id, 'approved', now() FROM users WHERE user_type = 'manager';
@@ -0,0 +1,5 @@
+DROP TABLE building_staff;
+
+DROP TABLE role_permissions;
+
+DROP TABLE roles;
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS roles(
+    id serial not null unique,
+    role_name varchar(100) not null unique,
+    description text not null default ''
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions(
+    role_id int references roles(id) on delete cascade not null,
+    permission varchar(100) not null,
+    unique (role_id, permission)
+);
+
+CREATE TABLE IF NOT EXISTS building_staff(
+    id serial not null unique,
+    building_id int references buildings(id) on delete cascade not null,
+    user_id int references users(id) on delete cascade not null,
+    role_id int references roles(id) on delete cascade not null,
+    created_at timestamp with time zone default current_timestamp,
+    unique (building_id, user_id)
+);
+
+INSERT INTO roles(role_name, description) VALUES
+    ('co_owner', 'edits the venue, its pitches and sees bookings'),
+    ('front_desk', 'sees bookings of the venue'),
+    ('pitch_manager', 'manages pitches and sees bookings');
+
+INSERT INTO role_permissions(role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+INNER JOIN (VALUES
+    ('co_owner', 'building.edit'),
+    ('co_owner', 'pitch.manage'),
+    ('co_owner', 'order.view'),
+    ('front_desk', 'order.view'),
+    ('pitch_manager', 'pitch.manage'),
+    ('pitch_manager', 'order.view')
+) AS p(role_name, permission)
+ON r.role_name = p.role_name;
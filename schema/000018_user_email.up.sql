@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN email          varchar(255) not null default '',
+    ADD COLUMN email_verified boolean      not null default false;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS two_factor(
+    user_id int references users(id) on delete cascade not null unique,
+    secret varchar(64) not null,
+    enabled boolean not null default false,
+    enabled_at timestamp with time zone,
+    created_at timestamp with time zone default current_timestamp
+);
+
+CREATE TABLE IF NOT EXISTS recovery_codes(
+    id serial not null unique,
+    user_id int references users(id) on delete cascade not null,
+    code_hash varchar(255) not null,
+    used_at timestamp with time zone
+);
@@ -0,0 +1,3 @@
+DELETE FROM orders WHERE card_id IS NULL;
+
+ALTER TABLE orders ALTER COLUMN card_id SET NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE orders ALTER COLUMN card_id DROP NOT NULL;
@@ -0,0 +1,5 @@
+DROP TRIGGER audit_log_no_update ON audit_log;
+
+DROP FUNCTION audit_log_immutable();
+
+DROP TABLE audit_log;
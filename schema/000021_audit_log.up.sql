@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS audit_log(
+    id bigserial not null unique,
+    actor_id int,
+    actor_type varchar(20) not null default '',
+    action varchar(100) not null,
+    target_type varchar(50) not null default '',
+    target_id int,
+    ip varchar(64) not null default '',
+    user_agent text not null default '',
+    outcome varchar(20) not null,
+    details text not null default '',
+    created_at timestamp with time zone not null default current_timestamp
+);
+
+CREATE INDEX audit_log_actor_idx ON audit_log(actor_id, created_at);
+CREATE INDEX audit_log_target_idx ON audit_log(target_type, target_id, created_at);
+CREATE INDEX audit_log_action_idx ON audit_log(action, created_at);
+CREATE INDEX audit_log_created_at_idx ON audit_log(created_at);
+
+-- entries are never changed, only removed by the retention job
+CREATE FUNCTION audit_log_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_immutable();
@@ -0,0 +1,5 @@
+DROP TABLE api_key_permissions;
+
+DROP TABLE api_key_buildings;
+
+DROP TABLE api_keys;
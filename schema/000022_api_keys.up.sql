@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS api_keys(
+    id serial not null unique,
+    user_id int references users(id) on delete cascade not null,
+    key_name varchar(100) not null,
+    prefix varchar(20) not null,
+    key_hash varchar(64) not null unique,
+    created_at timestamp with time zone not null default current_timestamp,
+    last_used_at timestamp with time zone,
+    last_used_ip varchar(64) not null default '',
+    revoked_at timestamp with time zone
+);
+
+CREATE INDEX api_keys_user_idx ON api_keys(user_id);
+
+CREATE TABLE IF NOT EXISTS api_key_buildings(
+    api_key_id int references api_keys(id) on delete cascade not null,
+    building_id int references buildings(id) on delete cascade not null,
+    primary key (api_key_id, building_id)
+);
+
+CREATE TABLE IF NOT EXISTS api_key_permissions(
+    api_key_id int references api_keys(id) on delete cascade not null,
+    permission varchar(50) not null,
+    primary key (api_key_id, permission)
+);
@@ -0,0 +1,19 @@
+DELETE FROM comments WHERE user_id IS NULL;
+
+ALTER TABLE comments DROP CONSTRAINT comments_user_id_fkey;
+
+ALTER TABLE comments ADD CONSTRAINT comments_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE comments ALTER COLUMN user_id SET NOT NULL;
+
+ALTER TABLE orders DROP CONSTRAINT orders_card_id_fkey;
+
+ALTER TABLE orders ADD CONSTRAINT orders_card_id_fkey FOREIGN KEY (card_id) REFERENCES cards(id);
+
+DELETE FROM orders WHERE user_id IS NULL;
+
+ALTER TABLE orders DROP CONSTRAINT orders_user_id_fkey;
+
+ALTER TABLE orders ADD CONSTRAINT orders_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+
+ALTER TABLE orders ALTER COLUMN user_id SET NOT NULL;
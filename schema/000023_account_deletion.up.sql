@@ -0,0 +1,16 @@
+-- orders and reviews outlive the account, they are anonymized instead of deleted
+ALTER TABLE orders ALTER COLUMN user_id DROP NOT NULL;
+
+ALTER TABLE orders DROP CONSTRAINT orders_user_id_fkey;
+
+ALTER TABLE orders ADD CONSTRAINT orders_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE orders DROP CONSTRAINT orders_card_id_fkey;
+
+ALTER TABLE orders ADD CONSTRAINT orders_card_id_fkey FOREIGN KEY (card_id) REFERENCES cards(id) ON DELETE SET NULL;
+
+ALTER TABLE comments ALTER COLUMN user_id DROP NOT NULL;
+
+ALTER TABLE comments DROP CONSTRAINT comments_user_id_fkey;
+
+ALTER TABLE comments ADD CONSTRAINT comments_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
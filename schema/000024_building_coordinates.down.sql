@@ -0,0 +1,11 @@
+DROP INDEX buildings_coordinates_idx;
+
+ALTER TABLE buildings DROP CONSTRAINT buildings_coordinates_check;
+
+ALTER TABLE buildings ALTER COLUMN longtitude TYPE varchar(255) USING coalesce(longtitude::text, '');
+
+ALTER TABLE buildings ALTER COLUMN latitude TYPE varchar(255) USING coalesce(latitude::text, '');
+
+ALTER TABLE buildings ALTER COLUMN longtitude SET NOT NULL;
+
+ALTER TABLE buildings ALTER COLUMN latitude SET NOT NULL;
@@ -0,0 +1,19 @@
+-- coordinates that are not numbers can not be placed on the map, they are dropped
+ALTER TABLE buildings ALTER COLUMN longtitude DROP NOT NULL;
+
+ALTER TABLE buildings ALTER COLUMN latitude DROP NOT NULL;
+
+ALTER TABLE buildings ALTER COLUMN longtitude TYPE double precision
+    USING CASE WHEN trim(longtitude) ~ '^-?[0-9]+(\.[0-9]+)?$' THEN trim(longtitude)::double precision END;
+
+ALTER TABLE buildings ALTER COLUMN latitude TYPE double precision
+    USING CASE WHEN trim(latitude) ~ '^-?[0-9]+(\.[0-9]+)?$' THEN trim(latitude)::double precision END;
+
+UPDATE buildings SET longtitude = NULL, latitude = NULL
+    WHERE longtitude NOT BETWEEN -180 AND 180 OR latitude NOT BETWEEN -90 AND 90
+       OR longtitude IS NULL OR latitude IS NULL;
+
+ALTER TABLE buildings ADD CONSTRAINT buildings_coordinates_check
+    CHECK (latitude BETWEEN -90 AND 90 AND longtitude BETWEEN -180 AND 180);
+
+CREATE INDEX buildings_coordinates_idx ON buildings(latitude, longtitude);
@@ -0,0 +1,5 @@
+DROP INDEX buildings_address_trgm_idx;
+
+DROP INDEX buildings_name_trgm_idx;
+
+DROP INDEX buildings_search_idx;
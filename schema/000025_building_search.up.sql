@@ -0,0 +1,12 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- the expression has to match buildingSearchVector in internal/repository/search.go for the index to be used
+CREATE INDEX buildings_search_idx ON buildings USING gin ((
+    setweight(to_tsvector('russian', building_name), 'A') || setweight(to_tsvector('english', building_name), 'A') ||
+    setweight(to_tsvector('russian', address), 'B') || setweight(to_tsvector('english', address), 'B') ||
+    setweight(to_tsvector('russian', description), 'C') || setweight(to_tsvector('english', description), 'C')
+));
+
+CREATE INDEX buildings_name_trgm_idx ON buildings USING gin (building_name gin_trgm_ops);
+
+CREATE INDEX buildings_address_trgm_idx ON buildings USING gin (address gin_trgm_ops);
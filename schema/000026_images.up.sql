@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS images(
+    id serial primary key,
+    building_id int references buildings(id) on delete cascade,
+    pitch_id int references pitches(id) on delete cascade,
+    image text not null,
+    position int not null default 0,
+    is_cover boolean not null default false,
+    created_at timestamp not null default now(),
+    CHECK ( (building_id IS NULL) <> (pitch_id IS NULL) )
+);
+
+CREATE INDEX images_building_idx ON images(building_id, position);
+
+CREATE INDEX images_pitch_idx ON images(pitch_id, position);
+
+-- the cover is also kept in building_image and pitch_image so the listings stay as they are
+CREATE UNIQUE INDEX images_building_cover_idx ON images(building_id) WHERE is_cover;
+
+CREATE UNIQUE INDEX images_pitch_cover_idx ON images(pitch_id) WHERE is_cover;
+
+INSERT INTO images(building_id, image, is_cover)
+    SELECT id, building_image, true FROM buildings WHERE building_image <> '';
+
+INSERT INTO images(pitch_id, image, is_cover)
+    SELECT id, pitch_image, true FROM pitches WHERE pitch_image <> '';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS times(
+    id serial not null unique ,
+    work_time time,
+    building_id int references buildings(id) on delete cascade not null
+);
+
+INSERT INTO times(work_time, building_id)
+    SELECT s.work_time::time, b.id
+    FROM buildings b,
+         generate_series(date '2000-01-01' + b.start_time, date '2000-01-01' + b.end_time, interval '30 minutes') AS s(work_time)
+    ORDER BY b.id, s.work_time;
+
+DROP TABLE building_exceptions;
+
+DROP TABLE building_hours;
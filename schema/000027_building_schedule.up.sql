@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS building_hours(
+    building_id int references buildings(id) on delete cascade not null,
+    weekday smallint not null check ( weekday >= 1 and 7 >= weekday ),
+    is_closed boolean not null default false,
+    open_time time not null default '00:00',
+    close_time time not null default '24:00',
+    primary key (building_id, weekday),
+    CHECK ( is_closed OR open_time < close_time )
+);
+
+CREATE TABLE IF NOT EXISTS building_exceptions(
+    id serial primary key,
+    building_id int references buildings(id) on delete cascade not null,
+    exception_date date not null,
+    is_closed boolean not null default true,
+    open_time time,
+    close_time time,
+    reason varchar(255) not null default '',
+    unique (building_id, exception_date),
+    CHECK ( is_closed OR (open_time IS NOT NULL AND close_time IS NOT NULL AND open_time < close_time) )
+);
+
+-- the old slot rows started up to and including end_time, so the last slot ends half an hour later
+INSERT INTO building_hours(building_id, weekday, is_closed, open_time, close_time)
+    SELECT
+        b.id,
+        d.weekday,
+        b.start_time >= b.end_time,
+        b.start_time,
+        CASE WHEN b.end_time >= time '23:30' THEN time '24:00' ELSE b.end_time + interval '30 minutes' END
+    FROM buildings b, generate_series(1, 7) AS d(weekday);
+
+DROP TABLE times;
@@ -0,0 +1,5 @@
+DELETE FROM orders WHERE status = 3;
+
+ALTER TABLE orders DROP CONSTRAINT orders_status_check;
+
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK ( status >= 1 and 2 >= status );
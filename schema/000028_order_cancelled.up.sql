@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP CONSTRAINT orders_status_check;
+
+-- 3 is an order cancelled by the venue, it stays in the history of the user
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK ( status >= 1 and 3 >= status );
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS order_times (
+    id serial not null unique ,
+    order_work_time time,
+    order_id int references orders(id) on delete cascade not null
+);
+
+INSERT INTO order_times(order_work_time, order_id)
+    SELECT
+        s.slot::time,
+        o.id
+    FROM orders o, generate_series(o.start_order_date, o.end_order_date - interval '30 minutes', interval '30 minutes') AS s(slot);
+
+UPDATE orders SET end_order_date = end_order_date - interval '30 minutes' WHERE end_order_date > start_order_date;
+
+ALTER TABLE orders DROP COLUMN start_order_date;
+
+ALTER TABLE pitches
+    DROP COLUMN slot_length,
+    DROP COLUMN min_duration,
+    DROP COLUMN max_duration;
+
+ALTER TABLE buildings
+    DROP COLUMN slot_length,
+    DROP COLUMN min_duration,
+    DROP COLUMN max_duration;
@@ -0,0 +1,34 @@
+-- durations are in minutes, a building without max_duration does not limit the booking
+ALTER TABLE buildings
+    ADD COLUMN slot_length int not null default 30 check ( slot_length in (30, 60, 90) ),
+    ADD COLUMN min_duration int not null default 30 check ( min_duration > 0 ),
+    ADD COLUMN max_duration int check ( max_duration >= min_duration );
+
+-- a pitch uses the value of its building where its own is null
+ALTER TABLE pitches
+    ADD COLUMN slot_length int check ( slot_length in (30, 60, 90) ),
+    ADD COLUMN min_duration int check ( min_duration > 0 ),
+    ADD COLUMN max_duration int check ( max_duration > 0 );
+
+ALTER TABLE orders ADD COLUMN start_order_date timestamp with time zone;
+
+-- end_order_date used to be the start of the last half-hour slot
+UPDATE orders o SET
+    start_order_date = o.order_date + t.first_time,
+    end_order_date = o.order_date + t.last_time + interval '30 minutes'
+FROM (
+    SELECT
+        order_id,
+        min(order_work_time) - time '00:00' AS first_time,
+        max(order_work_time) - time '00:00' AS last_time
+    FROM order_times
+    GROUP BY order_id
+) t
+WHERE t.order_id = o.id;
+
+UPDATE orders SET
+    start_order_date = order_date,
+    end_order_date = coalesce(end_order_date, order_date)
+WHERE start_order_date IS NULL;
+
+DROP TABLE order_times;
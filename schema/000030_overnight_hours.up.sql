@@ -0,0 +1,9 @@
+-- a close time not after the open time is on the next day, the day runs past midnight
+ALTER TABLE building_hours DROP CONSTRAINT building_hours_check;
+
+ALTER TABLE building_hours ADD CONSTRAINT building_hours_check CHECK ( is_closed OR open_time <> close_time );
+
+ALTER TABLE building_exceptions DROP CONSTRAINT building_exceptions_check;
+
+ALTER TABLE building_exceptions ADD CONSTRAINT building_exceptions_check
+    CHECK ( is_closed OR (open_time IS NOT NULL AND close_time IS NOT NULL AND open_time <> close_time) );
@@ -0,0 +1,6 @@
+UPDATE orders SET
+    order_date = order_date at time zone 'GMT',
+    start_order_date = start_order_date at time zone 'GMT',
+    end_order_date = end_order_date at time zone 'GMT';
+
+ALTER TABLE buildings DROP COLUMN timezone;
@@ -0,0 +1,8 @@
+-- IANA name, the opening hours and the slots of the building are in its local time
+ALTER TABLE buildings ADD COLUMN timezone varchar(64) not null default 'Asia/Almaty';
+
+-- the order times were written as UTC wall time in the session time zone, store the instants instead
+UPDATE orders SET
+    order_date = order_date::timestamp at time zone 'GMT',
+    start_order_date = start_order_date::timestamp at time zone 'GMT',
+    end_order_date = end_order_date::timestamp at time zone 'GMT';
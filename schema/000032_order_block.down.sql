@@ -0,0 +1,3 @@
+DELETE FROM api_key_permissions WHERE permission = 'order.block';
+
+DELETE FROM role_permissions WHERE permission = 'order.block';
@@ -0,0 +1,5 @@
+-- order.block lets the venue or an integration take times of a pitch without an app order
+INSERT INTO role_permissions(role_id, permission)
+SELECT r.id, 'order.block'
+FROM roles r
+WHERE r.role_name IN ('co_owner', 'front_desk');
@@ -0,0 +1 @@
+ALTER TABLE orders DROP CONSTRAINT orders_no_overlap;
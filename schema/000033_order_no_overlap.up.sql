@@ -0,0 +1,23 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- orders used to be stored without an overlap check, the earliest order of the overlapping ones
+-- keeps its time and the later ones are cancelled, they stay cancelled after a rollback
+DO $$
+DECLARE
+    o record;
+BEGIN
+    FOR o IN SELECT id, pitch_id, start_order_date, end_order_date FROM orders WHERE status <> 3 ORDER BY id LOOP
+        UPDATE orders SET status = 3
+        WHERE id = o.id AND EXISTS (
+            SELECT 1 FROM orders e
+            WHERE e.pitch_id = o.pitch_id AND e.status <> 3 AND e.id < o.id
+                AND tstzrange(e.start_order_date, e.end_order_date) && tstzrange(o.start_order_date, o.end_order_date)
+        );
+    END LOOP;
+END $$;
+
+-- checking for overlaps before the insert lets two concurrent orders through, the constraint does not
+ALTER TABLE orders ADD CONSTRAINT orders_no_overlap EXCLUDE USING gist (
+    pitch_id WITH =,
+    tstzrange(start_order_date, end_order_date) WITH &&
+) WHERE ( status <> 3 );
@@ -0,0 +1 @@
+-- the business dates stay as they are, the earlier values can not be told apart from them
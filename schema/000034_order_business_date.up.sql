@@ -0,0 +1,7 @@
+-- order_date is the business date of the building as UTC midnight, orders stored before the
+-- timezone of the building was known still have the local midnight as an instant
+UPDATE orders o SET
+    order_date = ((o.start_order_date AT TIME ZONE b.timezone)::date)::timestamp AT TIME ZONE 'UTC'
+FROM pitches p
+JOIN buildings b ON p.building_id = b.id
+WHERE o.pitch_id = p.id AND o.start_order_date IS NOT NULL;